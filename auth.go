@@ -0,0 +1,93 @@
+package coingecko
+
+import (
+	"fmt"
+	"golang.org/x/time/rate"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Plan CoinGecko API plan, selects base URL, auth header and default rate limit
+type Plan string
+
+const (
+	// PlanPublic keyless public API
+	PlanPublic Plan = "public"
+	// PlanDemo free Demo API key, sent as x-cg-demo-api-key
+	PlanDemo Plan = "demo"
+	// PlanPro paid Analyst/Lite/Pro API key, sent as x-cg-pro-api-key to pro-api.coingecko.com
+	PlanPro Plan = "pro"
+)
+
+const (
+	PublicBaseUrl = "https://api.coingecko.com/api/v3"
+	ProBaseUrl    = "https://pro-api.coingecko.com/api/v3"
+)
+
+const (
+	demoAPIKeyHeader = "x-cg-demo-api-key"
+	proAPIKeyHeader  = "x-cg-pro-api-key"
+)
+
+// plan resolves the configured plan, an APIKey without Plan is treated as a Demo key
+func (cfg Config) plan() Plan {
+	switch {
+	case cfg.Plan != "":
+		return cfg.Plan
+	case cfg.APIKey != "":
+		return PlanDemo
+	}
+	return PlanPublic
+}
+
+// baseUrl default base URL for the plan
+func (p Plan) baseUrl() string {
+	if p == PlanPro {
+		return ProBaseUrl
+	}
+	return PublicBaseUrl
+}
+
+// rateLimiter default limiter matching the plan's published rate limit
+func (p Plan) rateLimiter() *rate.Limiter {
+	switch p {
+	case PlanPro:
+		// Analyst plan starts at 500 calls/minute.
+		return rate.NewLimiter(rate.Every(time.Minute/500), 1)
+	case PlanDemo:
+		// Demo plan has a rate limit of 30 calls/minute.
+		return rate.NewLimiter(rate.Every(time.Minute/30), 1)
+	}
+	//Our Free API* has a rate limit of 50 calls/minute.
+	return rate.NewLimiter(rate.Every(time.Millisecond*800), 1)
+}
+
+// requirePro fails with ErrPlanRestricted unless the client is on the Pro plan
+func (c *Client) requirePro(route string) error {
+	if c.cfg.plan() != PlanPro {
+		return fmt.Errorf("%w: %s requires a Pro API key", ErrPlanRestricted, route)
+	}
+	return nil
+}
+
+// setAuth adds the API key header for the configured plan
+func (c *Client) setAuth(req *http.Request) {
+	if c.cfg.APIKey == "" {
+		return
+	}
+	switch c.cfg.plan() {
+	case PlanPro:
+		req.Header.Set(proAPIKeyHeader, c.cfg.APIKey)
+	case PlanDemo:
+		req.Header.Set(demoAPIKeyHeader, c.cfg.APIKey)
+	}
+}
+
+// redactKey removes the configured API key from s
+func (c *Client) redactKey(s string) string {
+	if c.cfg.APIKey == "" {
+		return s
+	}
+	return strings.ReplaceAll(s, c.cfg.APIKey, "[REDACTED]")
+}
@@ -0,0 +1,66 @@
+package coingecko
+
+import (
+	"bytes"
+	"context"
+	"golang.org/x/time/rate"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlanDefaults(t *testing.T) {
+	tests := []struct {
+		cfg   Config
+		base  string
+		every time.Duration
+	}{
+		{Config{}, PublicBaseUrl, 800 * time.Millisecond},
+		{Config{APIKey: "k"}, PublicBaseUrl, 2 * time.Second},
+		{Config{APIKey: "k", Plan: PlanPro}, ProBaseUrl, 120 * time.Millisecond},
+		{Config{APIKey: "k", Plan: PlanPro, BaseUrl: "http://localhost"}, "http://localhost", 120 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		c := NewClient(tt.cfg)
+		if c.cfg.BaseUrl != tt.base {
+			t.Errorf("%+v: BaseUrl = %s, want %s", tt.cfg, c.cfg.BaseUrl, tt.base)
+		}
+		if got := c.rateLimiter.Limit(); got != rate.Every(tt.every) {
+			t.Errorf("%+v: limit = %v, want %v", tt.cfg, got, rate.Every(tt.every))
+		}
+	}
+}
+
+func TestAPIKeyHeader(t *testing.T) {
+	tests := []struct {
+		plan   Plan
+		header string
+	}{
+		{PlanDemo, "X-Cg-Demo-Api-Key"},
+		{PlanPro, "X-Cg-Pro-Api-Key"},
+	}
+	for _, tt := range tests {
+		var got http.Header
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r.Header.Clone()
+			_, _ = w.Write([]byte(`{"gecko_says":"(V3) To the Moon!","echo":"` + r.Header.Get(tt.header) + `"}`))
+		}))
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: LevelBody}))
+		c := NewClient(Config{BaseUrl: srv.URL, APIKey: "secret-key", Plan: tt.plan, Logger: logger, RateLimiter: rate.NewLimiter(rate.Inf, 1)})
+		_, err := c.Ping(context.Background())
+		srv.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Get(tt.header) != "secret-key" || len(got.Values("X-Cg-Pro-Api-Key"))+len(got.Values("X-Cg-Demo-Api-Key")) != 1 {
+			t.Errorf("%s: headers %v", tt.plan, got)
+		}
+		if strings.Contains(buf.String(), "secret-key") || !strings.Contains(buf.String(), "[REDACTED]") {
+			t.Errorf("%s: key not redacted in body log:\n%s", tt.plan, buf.String())
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package coingecko
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// PriceBatchConfig opt-in micro-batching of SimplePrice and SimpleSinglePrice
+// calls. Calls made within Window are merged into as few /simple/price
+// requests as MaxURLLength allows and each caller gets back its own slice of
+// the result. Calls whose context carries cache options such as WithoutCache
+// are not batched.
+type PriceBatchConfig struct {
+	// Window how long the first call of a batch waits for others, default 50ms
+	Window time.Duration
+	// MaxURLLength upper bound for the length of a merged request URL, default 2000
+	MaxURLLength int
+}
+
+const (
+	defaultPriceBatchWindow = 50 * time.Millisecond
+	defaultMaxURLLength     = 2000
+)
+
+type priceBatcher struct {
+	c       *Client
+	window  time.Duration
+	maxLen  int
+	mu      sync.Mutex
+	pending []*priceCall
+}
+
+type priceCall struct {
+	ctx  context.Context
+	ids  []string
+	vs   []string
+	done chan struct{}
+	data map[string]map[string]float32
+	err  error
+}
+
+func newPriceBatcher(c *Client, cfg PriceBatchConfig) *priceBatcher {
+	b := &priceBatcher{c: c, window: cfg.Window, maxLen: cfg.MaxURLLength}
+	if b.window <= 0 {
+		b.window = defaultPriceBatchWindow
+	}
+	if b.maxLen <= 0 {
+		b.maxLen = defaultMaxURLLength
+	}
+	return b
+}
+
+// do queues a SimplePrice call and waits for the batch it ends up in
+func (b *priceBatcher) do(ctx context.Context, ids []string, vsCurrencies []string) (map[string]map[string]float32, error) {
+	call := &priceCall{ctx: ctx, ids: ids, vs: vsCurrencies, done: make(chan struct{})}
+	b.mu.Lock()
+	b.pending = append(b.pending, call)
+	if len(b.pending) == 1 {
+		time.AfterFunc(b.window, b.flush)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.data, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// flush sends the pending calls as merged requests and fans the results out
+func (b *priceBatcher) flush() {
+	b.mu.Lock()
+	calls := b.pending
+	b.pending = nil
+	b.mu.Unlock()
+
+	live := calls[:0]
+	for _, call := range calls {
+		if call.ctx.Err() == nil {
+			live = append(live, call)
+		}
+	}
+	if len(live) == 0 {
+		return
+	}
+	ids, vs := mergeUnique(live, func(c *priceCall) []string { return c.ids }), mergeUnique(live, func(c *priceCall) []string { return c.vs })
+
+	// the merged request outlives any single caller, it keeps the values of the
+	// first one and is cancelled once every caller has given up
+	ctx, cancel := context.WithCancel(detachedContext{live[0].ctx})
+	defer cancel()
+	waiting := int32(len(live))
+	for _, call := range live {
+		go func(call *priceCall) {
+			select {
+			case <-call.ctx.Done():
+				if atomic.AddInt32(&waiting, -1) == 0 {
+					cancel()
+				}
+			case <-ctx.Done():
+			}
+		}(call)
+	}
+	result := make(map[string]map[string]float32)
+	failed := make(map[string]error)
+	for _, chunk := range b.chunkIDs(ids, vs) {
+		res, err := b.c.simplePrice(ctx, chunk, vs)
+		for _, id := range chunk {
+			if err != nil {
+				failed[id] = err
+			} else if prices, ok := res[id]; ok {
+				result[id] = prices
+			}
+		}
+	}
+
+	for _, call := range live {
+		data := make(map[string]map[string]float32, len(call.ids))
+		for _, id := range call.ids {
+			if err := failed[id]; err != nil {
+				call.err = err
+				break
+			}
+			prices, ok := result[id]
+			if !ok {
+				continue
+			}
+			sub := make(map[string]float32, len(call.vs))
+			for _, v := range call.vs {
+				if p, ok := prices[v]; ok {
+					sub[v] = p
+				}
+			}
+			data[id] = sub
+		}
+		if call.err == nil {
+			call.data = data
+		}
+		close(call.done)
+	}
+}
+
+// chunkIDs splits ids so every /simple/price URL stays within maxLen
+func (b *priceBatcher) chunkIDs(ids []string, vs []string) [][]string {
+	params := url.Values{}
+	params.Add("ids", "")
+	params.Add("vs_currencies", strings.Join(vs, ","))
+	base := len(b.c.cfg.BaseUrl) + len("/simple/price?") + len(params.Encode())
+	sep := len(url.QueryEscape(","))
+
+	var chunks [][]string
+	var chunk []string
+	size := base
+	for _, id := range ids {
+		n := len(url.QueryEscape(id))
+		if len(chunk) > 0 {
+			n += sep
+		}
+		if len(chunk) > 0 && size+n > b.maxLen {
+			chunks = append(chunks, chunk)
+			chunk, size, n = nil, base, n-sep
+		}
+		chunk = append(chunk, id)
+		size += n
+	}
+	if len(chunk) > 0 {
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// mergeUnique returns the sorted union of the values picked from calls
+func mergeUnique(calls []*priceCall, pick func(*priceCall) []string) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, call := range calls {
+		for _, v := range pick(call) {
+			if !seen[v] {
+				seen[v] = true
+				out = append(out, v)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,187 @@
+package coingecko
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"golang.org/x/time/rate"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var testPrices = map[string]map[string]float32{
+	"bitcoin":  {"usd": 20000, "eur": 19000},
+	"ethereum": {"usd": 1500, "eur": 1400},
+	"solana":   {"usd": 20, "eur": 19},
+}
+
+func newPriceServer(t *testing.T) (*httptest.Server, func() []string) {
+	var mu sync.Mutex
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		mu.Unlock()
+		res := map[string]map[string]float32{}
+		for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+			if p, ok := testPrices[id]; ok {
+				res[id] = map[string]float32{}
+				for _, v := range strings.Split(r.URL.Query().Get("vs_currencies"), ",") {
+					res[id][v] = p[v]
+				}
+			}
+		}
+		_ = json.NewEncoder(w).Encode(res)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), queries...)
+	}
+}
+
+func TestPriceBatch(t *testing.T) {
+	srv, queries := newPriceServer(t)
+	c := NewClient(Config{
+		BaseUrl:     srv.URL,
+		RateLimiter: rate.NewLimiter(rate.Inf, 1),
+		PriceBatch:  &PriceBatchConfig{Window: time.Minute},
+	})
+
+	tests := []struct {
+		id, vs string
+		want   float32
+	}{
+		{"bitcoin", "usd", 20000},
+		{"ethereum", "eur", 1400},
+		{"solana", "usd", 20},
+	}
+	var wg sync.WaitGroup
+	for _, tt := range tests {
+		wg.Add(1)
+		go func(id, vs string, want float32) {
+			defer wg.Done()
+			got, err := c.SimpleSinglePrice(context.Background(), id, vs)
+			if err != nil {
+				t.Errorf("%s/%s: %v", id, vs, err)
+				return
+			}
+			if got.MarketPrice != want {
+				t.Errorf("%s/%s = %v, want %v", id, vs, got.MarketPrice, want)
+			}
+		}(tt.id, tt.vs, tt.want)
+	}
+	flushWhenQueued(t, c.priceBatcher, len(tests))
+	wg.Wait()
+
+	q := queries()
+	if len(q) != 1 {
+		t.Fatalf("upstream requests = %d, want 1: %v", len(q), q)
+	}
+	if q[0] != "ids=bitcoin%2Cethereum%2Csolana&vs_currencies=eur%2Cusd" {
+		t.Errorf("merged query = %s", q[0])
+	}
+
+	// each caller only gets the ids and currencies it asked for
+	var got *map[string]map[string]float32
+	var err error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		got, err = c.SimplePrice(context.Background(), []string{"bitcoin", "unknown"}, []string{"eur"})
+	}()
+	flushWhenQueued(t, c.priceBatcher, 1)
+	<-done
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*got) != 1 || len((*got)["bitcoin"]) != 1 || (*got)["bitcoin"]["eur"] != 19000 {
+		t.Errorf("got %v", *got)
+	}
+}
+
+// flushWhenQueued flushes the batch once n calls are pending, instead of
+// relying on all of them arriving within the window
+func flushWhenQueued(t *testing.T, b *priceBatcher, n int) {
+	t.Helper()
+	waitFor(t, func() bool {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return len(b.pending) == n
+	})
+	b.flush()
+}
+
+func TestPriceBatchChunks(t *testing.T) {
+	srv, queries := newPriceServer(t)
+	maxLen := len(srv.URL) + len("/simple/price?ids=bitcoin%2Cethereum&vs_currencies=usd")
+	c := NewClient(Config{
+		BaseUrl:     srv.URL,
+		RateLimiter: rate.NewLimiter(rate.Inf, 1),
+		PriceBatch:  &PriceBatchConfig{Window: time.Millisecond, MaxURLLength: maxLen},
+	})
+	got, err := c.SimplePrice(context.Background(), []string{"bitcoin", "ethereum", "solana"}, []string{"usd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*got) != 3 {
+		t.Errorf("got %v", *got)
+	}
+	q := queries()
+	if len(q) != 2 || q[0] != "ids=bitcoin%2Cethereum&vs_currencies=usd" || q[1] != "ids=solana&vs_currencies=usd" {
+		t.Errorf("queries = %v", q)
+	}
+}
+
+func TestPriceBatchAllCancelled(t *testing.T) {
+	upstreamDone := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(upstreamDone)
+	}))
+	defer srv.Close()
+	c := NewClient(Config{
+		BaseUrl:     srv.URL,
+		RateLimiter: rate.NewLimiter(rate.Inf, 1),
+		PriceBatch:  &PriceBatchConfig{Window: time.Millisecond},
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := c.SimplePrice(ctx, []string{"bitcoin"}, []string{"usd"}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v", err)
+	}
+	select {
+	case <-upstreamDone:
+	case <-time.After(5 * time.Second):
+		t.Error("merged request not cancelled after every caller left")
+	}
+}
+
+func TestPriceBatchCacheOptions(t *testing.T) {
+	srv, queries := newPriceServer(t)
+	c := NewClient(Config{
+		BaseUrl:     srv.URL,
+		RateLimiter: rate.NewLimiter(rate.Inf, 1),
+		PriceBatch:  &PriceBatchConfig{Window: 20 * time.Millisecond},
+	})
+	var wg sync.WaitGroup
+	for _, ctx := range []context.Context{context.Background(), WithoutCache(context.Background())} {
+		wg.Add(1)
+		go func(ctx context.Context) {
+			defer wg.Done()
+			if _, err := c.SimplePrice(ctx, []string{"bitcoin"}, []string{"usd"}); err != nil {
+				t.Error(err)
+			}
+		}(ctx)
+	}
+	wg.Wait()
+	if q := queries(); len(q) != 2 {
+		t.Errorf("upstream requests = %d, want 2: %v", len(q), q)
+	}
+}
@@ -0,0 +1,200 @@
+package coingecko
+
+import (
+	"container/list"
+	"context"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Cache stores raw response bodies keyed by normalized request URL.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// DefaultCacheTTL per route cache TTLs, routes not listed here are not cached.
+// Override per client with Config.CacheTTL and per call with WithCacheTTL.
+var DefaultCacheTTL = map[string]time.Duration{
+	RouteSimplePrice:                     30 * time.Second,
+	RouteSimpleSupportedVSCurrencies:     time.Hour,
+	RouteSimpleTokenPrice:                30 * time.Second,
+	RouteCoinsList:                       time.Hour,
+	RouteCoinsMarkets:                    time.Minute,
+	RouteCoinsID:                         time.Minute,
+	RouteCoinsIDTickers:                  time.Minute,
+	RouteCoinsIDHistory:                  24 * time.Hour,
+	RouteCoinsIDMarketChart:              5 * time.Minute,
+	RouteCoinsIDMarketChartRange:         5 * time.Minute,
+	RouteCoinsIDOHLC:                     5 * time.Minute,
+	RouteCoinsIDOHLCRange:                5 * time.Minute,
+	RouteCoinsIDContractAddress:          time.Minute,
+	RouteCoinsIDContractMarketChart:      5 * time.Minute,
+	RouteCoinsIDContractMarketChartRange: 5 * time.Minute,
+	RouteAssetPlatforms:                  time.Hour,
+	RouteCategoriesList:                  time.Hour,
+	RouteCategories:                      5 * time.Minute,
+	RouteExchanges:                       5 * time.Minute,
+	RouteExchangesIDTickers:              time.Minute,
+	RouteExchangesList:                   time.Hour,
+	RouteExchangesIDVolumeChart:          5 * time.Minute,
+	RouteExchangesIDVolumeChartRange:     5 * time.Minute,
+	RouteExchangesID:                     time.Minute,
+	RouteDerivatives:                     time.Minute,
+	RouteDerivativesExchanges:            5 * time.Minute,
+	RouteDerivativesExchangesID:          time.Minute,
+	RouteDerivativesExchangesList:        time.Hour,
+	RouteIndexes:                         time.Minute,
+	RouteIndexesMarketID:                 time.Minute,
+	RouteIndexesList:                     time.Hour,
+	RouteNFTsList:                        time.Hour,
+	RouteNFTsID:                          time.Minute,
+	RouteNFTsContractAddress:             time.Minute,
+	RouteExchangeRates:                   5 * time.Minute,
+	RouteSearch:                          10 * time.Minute,
+	RouteGlobal:                          time.Minute,
+}
+
+type cacheMode int
+
+const (
+	cacheDefault cacheMode = iota
+	cacheBypass
+	cacheRefresh
+)
+
+type cacheOptions struct {
+	mode cacheMode
+	ttl  time.Duration
+}
+
+type cacheOptionsKey struct{}
+
+func cacheOptionsFrom(ctx context.Context) cacheOptions {
+	o, _ := ctx.Value(cacheOptionsKey{}).(cacheOptions)
+	return o
+}
+
+// WithCacheTTL overrides the cache TTL of calls made with the returned context
+func WithCacheTTL(ctx context.Context, ttl time.Duration) context.Context {
+	o := cacheOptionsFrom(ctx)
+	o.ttl = ttl
+	return context.WithValue(ctx, cacheOptionsKey{}, o)
+}
+
+// WithoutCache makes calls with the returned context skip the cache entirely
+func WithoutCache(ctx context.Context) context.Context {
+	o := cacheOptionsFrom(ctx)
+	o.mode = cacheBypass
+	return context.WithValue(ctx, cacheOptionsKey{}, o)
+}
+
+// WithCacheRefresh makes calls with the returned context ignore cached
+// entries and store the fresh response
+func WithCacheRefresh(ctx context.Context) context.Context {
+	o := cacheOptionsFrom(ctx)
+	o.mode = cacheRefresh
+	return context.WithValue(ctx, cacheOptionsKey{}, o)
+}
+
+// cacheTTL returns the TTL for a call to route, 0 when the response must not be cached
+func (c *Client) cacheTTL(ctx context.Context, route string) (time.Duration, cacheMode) {
+	if c.cfg.Cache == nil {
+		return 0, cacheBypass
+	}
+	o := cacheOptionsFrom(ctx)
+	if o.mode == cacheBypass {
+		return 0, cacheBypass
+	}
+	if o.ttl > 0 {
+		return o.ttl, o.mode
+	}
+	if ttl, ok := c.cfg.CacheTTL[route]; ok {
+		return ttl, o.mode
+	}
+	return DefaultCacheTTL[route], o.mode
+}
+
+// normalizeURL sorts query parameters so equivalent requests share a key
+func normalizeURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	u.RawQuery = u.Query().Encode()
+	return u.String()
+}
+
+// LRUCache in-memory Cache evicting the least recently used entry when full
+type LRUCache struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[string]*list.Element
+	now   func() time.Time
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewLRUCache create new LRU cache holding up to size entries
+func NewLRUCache(size int) *LRUCache {
+	if size <= 0 {
+		size = 1000
+	}
+	return &LRUCache{size: size, ll: list.New(), items: make(map[string]*list.Element), now: time.Now}
+}
+
+// Get returns the value for key if present and not expired
+func (l *LRUCache) Get(key string) ([]byte, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	el, ok := l.items[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*lruEntry)
+	if !l.now().Before(e.expires) {
+		l.remove(el)
+		return nil, false
+	}
+	l.ll.MoveToFront(el)
+	return e.value, true
+}
+
+// Set stores value for key for ttl
+func (l *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	expires := l.now().Add(ttl)
+	if el, ok := l.items[key]; ok {
+		e := el.Value.(*lruEntry)
+		e.value, e.expires = value, expires
+		l.ll.MoveToFront(el)
+		return
+	}
+	l.items[key] = l.ll.PushFront(&lruEntry{key: key, value: value, expires: expires})
+	for l.ll.Len() > l.size {
+		l.remove(l.ll.Back())
+	}
+}
+
+// Len number of entries, including expired ones not yet evicted
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.ll.Len()
+}
+
+func (l *LRUCache) remove(el *list.Element) {
+	l.ll.Remove(el)
+	delete(l.items, el.Value.(*lruEntry).key)
+}
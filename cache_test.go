@@ -0,0 +1,105 @@
+package coingecko
+
+import (
+	"context"
+	"golang.org/x/time/rate"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMatchRoute(t *testing.T) {
+	tests := map[string]string{
+		"/ping":                         RoutePing,
+		"/coins/list":                   RouteCoinsList,
+		"/coins/markets":                RouteCoinsMarkets,
+		"/coins/bitcoin":                RouteCoinsID,
+		"/coins/categories":             RouteCategories,
+		"/coins/categories/list":        RouteCategoriesList,
+		"/coins/bitcoin/market_chart":   RouteCoinsIDMarketChart,
+		"/exchanges/binance":            RouteExchangesID,
+		"/something/unknown/entirely/x": "/something/unknown/entirely/x",
+	}
+	for path, want := range tests {
+		if got := matchRoute(path); got != want {
+			t.Errorf("matchRoute(%s) = %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestLRUCache(t *testing.T) {
+	now := time.Now()
+	l := NewLRUCache(2)
+	l.now = func() time.Time { return now }
+	l.Set("a", []byte("1"), time.Minute)
+	l.Set("b", []byte("2"), time.Second)
+	if _, ok := l.Get("a"); !ok {
+		t.Fatal("a missing")
+	}
+	l.Set("c", []byte("3"), time.Minute)
+	if _, ok := l.Get("b"); ok {
+		t.Error("b should have been evicted as least recently used")
+	}
+	now = now.Add(2 * time.Minute)
+	if _, ok := l.Get("a"); ok {
+		t.Error("a should have expired")
+	}
+	if l.Len() != 1 {
+		t.Errorf("Len = %d, want 1", l.Len())
+	}
+}
+
+func TestMakeReqCache(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.URL.Path == "/ping" {
+			_, _ = w.Write([]byte(`{"gecko_says":"(V3) To the Moon!"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":"bitcoin","symbol":"btc","name":"Bitcoin"}]`))
+	}))
+	defer srv.Close()
+	c := NewClient(Config{BaseUrl: srv.URL, RateLimiter: rate.NewLimiter(rate.Inf, 1), Cache: NewLRUCache(10)})
+	ctx := context.Background()
+
+	steps := []struct {
+		name  string
+		ctx   context.Context
+		calls int32
+	}{
+		{"miss", ctx, 1},
+		{"hit", ctx, 1},
+		{"bypass", WithoutCache(ctx), 2},
+		{"refresh", WithCacheRefresh(ctx), 3},
+		{"hit after refresh", ctx, 3},
+	}
+	for _, s := range steps {
+		list, err := c.CoinsList(s.ctx)
+		if err != nil {
+			t.Fatalf("%s: %v", s.name, err)
+		}
+		if len(list) != 1 || list[0].ID != "bitcoin" {
+			t.Errorf("%s: got %+v", s.name, list)
+		}
+		if got := atomic.LoadInt32(&calls); got != s.calls {
+			t.Errorf("%s: upstream calls = %d, want %d", s.name, got, s.calls)
+		}
+	}
+
+	// ping is not cacheable by default, but can be cached per call
+	for i := 0; i < 2; i++ {
+		_, _ = c.Ping(ctx)
+	}
+	if got := atomic.LoadInt32(&calls); got != 5 {
+		t.Errorf("ping should not be cached, calls = %d", got)
+	}
+	for i := 0; i < 2; i++ {
+		_, _ = c.Ping(WithCacheTTL(ctx, time.Minute))
+	}
+	if got := atomic.LoadInt32(&calls); got != 6 {
+		t.Errorf("ping with WithCacheTTL should be cached, calls = %d", got)
+	}
+}
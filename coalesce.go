@@ -0,0 +1,168 @@
+package coingecko
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+)
+
+// flightGroup collapses concurrent identical requests into one upstream call.
+// Unlike a plain singleflight every waiter returns as soon as its own context
+// is done, and the upstream call is cancelled only when no waiter is left.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	// done is closed once body and err are set or stream is handed over
+	done    chan struct{}
+	body    []byte
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+	// stream live response body, handed to the waiter when it is the only one
+	stream     io.Reader
+	streamDone chan error
+	// finished is closed when the shared call has returned
+	finished chan struct{}
+	// deadline latest deadline of the waiters, unbounded once one has none
+	deadline  time.Time
+	unbounded bool
+}
+
+// join records the deadline of a waiter's context
+func (call *flightCall) join(ctx context.Context) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		call.unbounded = true
+	} else if deadline.After(call.deadline) {
+		call.deadline = deadline
+	}
+}
+
+// do runs fetch once for all concurrent callers with the same key and passes
+// the response body to the consume func of each of them. When a single caller
+// is left once the response arrives the body is streamed straight into its
+// consume, otherwise it is read once and replayed to every caller.
+func (g *flightGroup) do(ctx context.Context, key string, consume func(io.Reader) error,
+	fetch func(ctx context.Context, consume func(io.Reader) error) error) error {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	call, ok := g.calls[key]
+	if ok {
+		call.waiters++
+		call.join(ctx)
+	} else {
+		// the shared call is not bound by any waiter's deadline, every waiter
+		// enforces its own and the call is cancelled once the last one has left
+		callCtx, cancel := context.WithCancel(detachedContext{ctx})
+		call = &flightCall{done: make(chan struct{}), waiters: 1, cancel: cancel, streamDone: make(chan error, 1), finished: make(chan struct{})}
+		call.join(ctx)
+		g.calls[key] = call
+		go g.run(flightContext{callCtx, g, call}, key, call, fetch)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		if call.stream != nil {
+			stop := context.AfterFunc(ctx, call.cancel)
+			err := consume(call.stream)
+			stop()
+			call.streamDone <- err
+			// the attempt is logged and measured once the body is consumed
+			<-call.finished
+			return err
+		}
+		if call.err != nil {
+			return call.err
+		}
+		return consume(bytes.NewReader(call.body))
+	case <-ctx.Done():
+		g.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+			call.cancel()
+		}
+		if call.stream != nil {
+			// the body was handed over just as this waiter gave up
+			call.streamDone <- ctx.Err()
+		}
+		g.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// run performs the shared call of key
+func (g *flightGroup) run(ctx context.Context, key string, call *flightCall,
+	fetch func(ctx context.Context, consume func(io.Reader) error) error) {
+	defer close(call.finished)
+	defer call.cancel()
+	err := fetch(ctx, func(body io.Reader) error {
+		g.mu.Lock()
+		if call.waiters == 1 {
+			// nobody joined, callers arriving from now on start a new call
+			if g.calls[key] == call {
+				delete(g.calls, key)
+			}
+			call.stream = body
+			g.mu.Unlock()
+			close(call.done)
+			return <-call.streamDone
+		}
+		g.mu.Unlock()
+		b, err := ioutil.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		call.body = b
+		return nil
+	})
+	g.mu.Lock()
+	if g.calls[key] == call {
+		delete(g.calls, key)
+	}
+	streamed := call.stream != nil
+	g.mu.Unlock()
+	if !streamed {
+		call.err = err
+		close(call.done)
+	}
+}
+
+// flightContext reports the latest deadline of the waiters of call without
+// enforcing it, so retries are not scheduled past the point anyone waits for
+type flightContext struct {
+	context.Context
+	g    *flightGroup
+	call *flightCall
+}
+
+func (f flightContext) Deadline() (time.Time, bool) {
+	f.g.mu.Lock()
+	defer f.g.mu.Unlock()
+	if f.call.unbounded {
+		return time.Time{}, false
+	}
+	return f.call.deadline, true
+}
+
+// detachedContext keeps the values of the parent context but not its
+// cancellation or deadline, so one waiter giving up does not fail the shared call.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool)         { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}               { return nil }
+func (detachedContext) Err() error                          { return nil }
+func (d detachedContext) Value(key interface{}) interface{} { return d.parent.Value(key) }
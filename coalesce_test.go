@@ -0,0 +1,156 @@
+package coingecko
+
+import (
+	"context"
+	"errors"
+	"golang.org/x/time/rate"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMakeReqCoalescing(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		_, _ = w.Write([]byte(`[{"id":"bitcoin","symbol":"btc","name":"Bitcoin","current_price":20000}]`))
+	}))
+	defer srv.Close()
+	c := NewClient(Config{BaseUrl: srv.URL, RateLimiter: rate.NewLimiter(rate.Inf, 1)})
+	req := CoinsMarketRequest{VsCurrency: "usd", Page: 1}
+
+	// a waiter that gives up must not fail the others
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancelledErr := make(chan error, 1)
+	go func() {
+		_, err := c.CoinsMarket(cancelled, req)
+		cancelledErr <- err
+	}()
+
+	var wg sync.WaitGroup
+	results := make([][]CoinsMarketItem, 20)
+	errs := make([]error, 20)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = c.CoinsMarket(context.Background(), req)
+		}(i)
+	}
+	waitFor(t, func() bool {
+		c.flights.mu.Lock()
+		defer c.flights.mu.Unlock()
+		for _, call := range c.flights.calls {
+			return call.waiters == 21
+		}
+		return false
+	})
+	cancel()
+	if err := <-cancelledErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("cancelled waiter got %v", err)
+	}
+	close(release)
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("upstream calls = %d, want 1", got)
+	}
+	for i := range results {
+		if errs[i] != nil || len(results[i]) != 1 || results[i][0].CurrentPrice != 20000 {
+			t.Fatalf("waiter %d got %v %+v", i, errs[i], results[i])
+		}
+	}
+	results[0][0].CurrentPrice = 1
+	if results[1][0].CurrentPrice != 20000 {
+		t.Error("waiters share decoded result")
+	}
+}
+
+func TestMakeReqCoalescingAllCancelled(t *testing.T) {
+	upstreamDone := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(upstreamDone)
+	}))
+	defer srv.Close()
+	c := NewClient(Config{BaseUrl: srv.URL, RateLimiter: rate.NewLimiter(rate.Inf, 1)})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	if _, err := c.CoinsList(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v", err)
+	}
+	select {
+	case <-upstreamDone:
+	case <-time.After(5 * time.Second):
+		t.Error("upstream request not cancelled after the last waiter left")
+	}
+}
+
+func TestMakeReqCoalescingOwnDeadline(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		_, _ = w.Write([]byte(`[{"id":"bitcoin","symbol":"btc","name":"Bitcoin"}]`))
+	}))
+	defer srv.Close()
+	c := NewClient(Config{BaseUrl: srv.URL, RateLimiter: rate.NewLimiter(rate.Inf, 1)})
+
+	// the waiter that starts the call has a short deadline, the one joining it has none
+	short, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	shortErr := make(chan error, 1)
+	go func() {
+		_, err := c.CoinsList(short)
+		shortErr <- err
+	}()
+	waitFor(t, func() bool {
+		c.flights.mu.Lock()
+		defer c.flights.mu.Unlock()
+		return len(c.flights.calls) == 1
+	})
+	type result struct {
+		list []CoinBaseStruct
+		err  error
+	}
+	long := make(chan result, 1)
+	go func() {
+		list, err := c.CoinsList(context.Background())
+		long <- result{list, err}
+	}()
+	waitFor(t, func() bool {
+		c.flights.mu.Lock()
+		defer c.flights.mu.Unlock()
+		for _, call := range c.flights.calls {
+			return call.waiters == 2
+		}
+		return false
+	})
+
+	if err := <-shortErr; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("short deadline waiter got %v", err)
+	}
+	close(release)
+	if got := <-long; got.err != nil || len(got.list) != 1 {
+		t.Errorf("waiter without deadline got %v %+v", got.err, got.list)
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -1,14 +1,15 @@
 package coingecko
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/time/rate"
+	"io"
 	"io/ioutil"
-	"log"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
 	"strconv"
 	"strings"
@@ -17,27 +18,71 @@ import (
 
 // Client struct
 type Client struct {
-	cfg         Config
-	client      *http.Client
-	rateLimiter *rate.Limiter
+	cfg          Config
+	client       *http.Client
+	rateLimiter  *rate.Limiter
+	flights      flightGroup
+	priceBatcher *priceBatcher
+	logger       Logger
+	metrics      Metrics
+	tracer       trace.Tracer
 }
 type Config struct {
-	BaseUrl     string
+	BaseUrl string
+	// Debug logs every request and response body to stderr when Logger is not set
 	Debug       bool
 	HttpClient  *http.Client
 	RateLimiter *rate.Limiter
+	// Retry policy for 429, 5xx and network errors, nil disables retries
+	Retry *RetryPolicy
+	// APIKey CoinGecko Demo or Pro API key
+	APIKey string
+	// Plan selects base URL, auth header and default rate limit, defaults to
+	// PlanDemo when APIKey is set and PlanPublic otherwise
+	Plan Plan
+	// Cache for responses of slow changing endpoints, nil disables caching
+	Cache Cache
+	// CacheTTL overrides DefaultCacheTTL per route, e.g. RouteCoinsList
+	CacheTTL map[string]time.Duration
+	// DisableCoalescing sends identical concurrent requests separately
+	// instead of sharing one upstream call
+	DisableCoalescing bool
+	// PriceBatch merges concurrent SimplePrice calls, nil disables batching
+	PriceBatch *PriceBatchConfig
+	// Logger receives a structured event per request and, at LevelBody,
+	// the response bodies. *slog.Logger satisfies it.
+	Logger Logger
+	// BodyLogLimit max bytes of a body logged at LevelBody, default 4096, negative for no limit
+	BodyLogLimit int
+	// Metrics receives per-endpoint request, retry and cache instrumentation
+	Metrics Metrics
+	// TracerProvider for OpenTelemetry spans, the global provider when nil
+	TracerProvider trace.TracerProvider
+	// MaxResponseSize max bytes of a response body, default DefaultMaxResponseSize, negative for no limit
+	MaxResponseSize int64
 }
 
+// DefaultMaxResponseSize limit of a response body when Config.MaxResponseSize is 0
+const DefaultMaxResponseSize = 32 << 20
+
 // NewClient create new client object
 func NewClient(cfg Config) *Client {
+	plan := cfg.plan()
 	if cfg.BaseUrl == "" {
-		cfg.BaseUrl = "https://api.coingecko.com/api/v3"
+		cfg.BaseUrl = plan.baseUrl()
 	}
 	if cfg.RateLimiter == nil {
-		//Our Free API* has a rate limit of 50 calls/minute.
-		cfg.RateLimiter = rate.NewLimiter(rate.Every(time.Millisecond*800), 1)
+		cfg.RateLimiter = plan.rateLimiter()
+	}
+	c := &Client{cfg: cfg, rateLimiter: cfg.RateLimiter, logger: cfg.Logger}
+	if c.logger == nil && cfg.Debug {
+		c.logger = debugLogger()
+	}
+	c.metrics = nopMetrics{}
+	if cfg.Metrics != nil {
+		c.metrics = routeMetrics{cfg.Metrics}
 	}
-	c := &Client{cfg: cfg, rateLimiter: cfg.RateLimiter}
+	c.tracer = newTracer(cfg.TracerProvider)
 	if cfg.HttpClient != nil {
 		c.client = cfg.HttpClient
 	} else {
@@ -48,129 +93,301 @@ func NewClient(cfg Config) *Client {
 		t.IdleConnTimeout = 0
 		c.client = &http.Client{Timeout: 10 * time.Second, Transport: t}
 	}
+	if cfg.PriceBatch != nil {
+		c.priceBatcher = newPriceBatcher(c, *cfg.PriceBatch)
+	}
 	return c
 }
 
-// MakeReq HTTP request helper
+// MakeReq HTTP request helper, non 200 responses are returned as *APIError.
+// Responses of cacheable routes are served from Config.Cache when present,
+// identical concurrent requests share one upstream call and failed attempts
+// are retried according to Config.Retry
 func (c *Client) MakeReq(ctx context.Context, url string, data interface{}) error {
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	return c.makeReq(ctx, c.routeOf(url), url, data)
+}
 
+// makeReq MakeReq for a URL whose route template is already known
+func (c *Client) makeReq(ctx context.Context, route string, url string, data interface{}) error {
+	r, err := c.newRequest(route, url)
+	if err != nil {
+		return err
+	}
+	ttl, mode := c.cacheTTL(ctx, r.route)
+	if ttl > 0 && mode != cacheRefresh {
+		if body, ok := c.cfg.Cache.Get(r.key); ok {
+			c.metrics.IncCacheHit(r.route)
+			c.logRequest(ctx, requestEvent{route: r.route, url: r.url, cacheHit: true, bytes: len(body)})
+			trace.SpanFromContext(ctx).SetAttributes(AttrCacheHit.Bool(true))
+			return c.decode(ctx, bytes.NewReader(body), data)
+		}
+	}
+	if ttl > 0 {
+		c.metrics.IncCacheMiss(r.route)
+		trace.SpanFromContext(ctx).SetAttributes(AttrCacheHit.Bool(false))
+	}
+	var body []byte
+	err = c.fetch(ctx, r, func(rd io.Reader) error {
+		if ttl > 0 {
+			// the cache needs the raw body, otherwise decode as it streams in
+			b, err := ioutil.ReadAll(rd)
+			if err != nil {
+				return err
+			}
+			body, rd = b, bytes.NewReader(b)
+		}
+		// every waiter of a shared call decodes its own copy, so results never alias
+		return c.decode(ctx, rd, data)
+	})
 	if err != nil {
 		return err
 	}
+	if ttl > 0 {
+		c.cfg.Cache.Set(r.key, body, ttl)
+	}
+	return nil
+}
+
+// decode reads a JSON response body into data
+func (c *Client) decode(ctx context.Context, body io.Reader, data interface{}) (err error) {
+	_, span := c.tracer.Start(ctx, "coingecko.decode")
+	defer func() { endSpan(span, err) }()
+	return json.NewDecoder(body).Decode(data)
+}
+
+// request prepared GET request
+type request struct {
+	// route template, e.g. /coins/{id}/market_chart
+	route string
+	url   *url.URL
+	// key normalized URL identifying identical requests
+	key string
+}
+
+func (c *Client) newRequest(route string, rawURL string) (*request, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	return &request{route: route, url: u, key: normalizeURL(rawURL)}, nil
+}
+
+// fetch passes the body of a successful response to consume, coalescing identical calls
+func (c *Client) fetch(ctx context.Context, r *request, consume func(io.Reader) error) error {
+	if c.cfg.DisableCoalescing {
+		return c.fetchRetry(ctx, r, consume)
+	}
+	return c.flights.do(ctx, r.key, consume, func(ctx context.Context, consume func(io.Reader) error) error {
+		return c.fetchRetry(ctx, r, consume)
+	})
+}
+
+// fetchRetry passes the body of a successful response to consume, retrying
+// failed attempts. Once consume has started reading the body there is no retry.
+func (c *Client) fetchRetry(ctx context.Context, r *request, consume func(io.Reader) error) error {
+	for attempt := 1; ; attempt++ {
+		consumed := false
+		err := c.doReq(ctx, r, attempt, func(body io.Reader) error {
+			consumed = true
+			return consume(body)
+		})
+		if err == nil || consumed || attempt >= c.cfg.Retry.maxAttempts() || !c.cfg.Retry.retryable(err) {
+			return err
+		}
+		if !sleepCtx(ctx, c.cfg.Retry.backoff(attempt, err)) {
+			return err
+		}
+		c.metrics.IncRetry(r.route)
+	}
+}
+
+// doReq makes a single attempt of the request and passes a successful response body to consume
+func (c *Client) doReq(ctx context.Context, r *request, attempt int, consume func(io.Reader) error) (err error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", r.url.String(), nil)
+
+	if err != nil {
+		return err
+	}
+	c.setAuth(req)
+	ev := requestEvent{route: r.route, url: r.url, attempt: attempt}
+	defer func() {
+		ev.err = err
+		if ev.latency > 0 {
+			// the request was sent, status is 0 on transport errors
+			c.metrics.ObserveRequest(r.route, ev.status, ev.latency)
+		}
+		c.logRequest(ctx, ev)
+	}()
 	start := time.Now()
+	_, waitSpan := c.tracer.Start(ctx, "coingecko.rate_limiter.wait", trace.WithAttributes(AttrAttempt.Int(attempt)))
 	err = c.rateLimiter.Wait(ctx) // This is a blocking call. Honors the rate limit
+	endSpan(waitSpan, err)
+	ev.wait = time.Since(start)
+	c.metrics.ObserveLimiterWait(r.route, ev.wait)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.client.Do(req)
+	start = time.Now()
+	httpCtx, httpSpan := c.tracer.Start(ctx, "coingecko.http", trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(AttrEndpoint.String(r.route), AttrAttempt.Int(attempt)))
+	defer func() { endSpan(httpSpan, err) }()
+	resp, err := c.client.Do(req.WithContext(httpCtx))
 	if err != nil {
+		ev.latency = time.Since(start)
 		return err
 	}
 	defer resp.Body.Close()
-	if c.cfg.Debug {
-		dump, err := httputil.DumpResponse(resp, true)
-		//_ = ioutil.WriteFile("test.json", dump, os.ModePerm)
+	ev.status = resp.StatusCode
+	httpSpan.SetAttributes(AttrStatusCode.Int(resp.StatusCode))
+
+	body := &limitedBody{r: resp.Body, limit: c.maxResponseSize(), url: r.url}
+	defer func() { ev.latency, ev.bytes = time.Since(start), int(body.n) }()
+	if resp.ContentLength > 0 && body.limit > 0 && resp.ContentLength > body.limit {
+		return &ResponseTooLargeError{Limit: body.limit, URL: redactURL(r.url)}
+	}
+	// the body is kept in memory only for error reports and body logging
+	if 200 != resp.StatusCode || c.logsBodies(ctx) {
+		b, err := ioutil.ReadAll(body)
 		if err != nil {
-			return fmt.Errorf("httputil.DumpResponse error: %w", err)
+			return err
+		}
+		c.logBody(ctx, r.route, r.url, b)
+		if 200 != resp.StatusCode {
+			return newAPIError(resp, b)
 		}
-		log.Printf("\nTotalTime: %s\n%s", time.Since(start), string(dump))
+		return consume(bytes.NewReader(b))
 	}
+	return consume(body)
+}
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
+// maxResponseSize limit of a response body in bytes, 0 for no limit
+func (c *Client) maxResponseSize() int64 {
+	switch {
+	case c.cfg.MaxResponseSize < 0:
+		return 0
+	case c.cfg.MaxResponseSize == 0:
+		return DefaultMaxResponseSize
 	}
-	if 200 != resp.StatusCode {
-		return fmt.Errorf("%s", body)
+	return c.cfg.MaxResponseSize
+}
+
+// limitedBody counts the bytes read from a response body and fails with
+// *ResponseTooLargeError once more than limit bytes arrive
+type limitedBody struct {
+	r     io.Reader
+	n     int64
+	limit int64
+	url   *url.URL
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.limit > 0 {
+		if l.n > l.limit {
+			return 0, &ResponseTooLargeError{Limit: l.limit, URL: redactURL(l.url)}
+		}
+		// read one byte past the limit to tell an exact fit from an overflow
+		if rest := l.limit + 1 - l.n; int64(len(p)) > rest {
+			p = p[:rest]
+		}
 	}
-	err = json.Unmarshal(body, data)
-	return err
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+	if l.limit > 0 && l.n > l.limit {
+		return n, &ResponseTooLargeError{Limit: l.limit, URL: redactURL(l.url)}
+	}
+	return n, err
 }
 
 // Ping /ping endpoint
-func (c *Client) Ping(ctx context.Context) (data *Ping, err error) {
-	err = c.MakeReq(ctx, fmt.Sprintf("%s/ping", c.cfg.BaseUrl), &data)
-	return
-}
-
-// SimplePrice /simple/price Multiple ID and Currency (ids, vs_currencies)
-func (c *Client) SimplePrice(ctx context.Context, ids []string, vsCurrencies []string) (*map[string]map[string]float32, error) {
-	params := url.Values{}
-	idsParam := strings.Join(ids[:], ",")
-	vsCurrenciesParam := strings.Join(vsCurrencies[:], ",")
-	params.Add("ids", idsParam)
-	params.Add("vs_currencies", vsCurrenciesParam)
-	t := make(map[string]map[string]float32)
-	err := c.MakeReq(ctx, fmt.Sprintf("%s/simple/price?%s", c.cfg.BaseUrl, params.Encode()), &t)
+func (c *Client) Ping(ctx context.Context) (*Ping, error) {
+	return Do(ctx, c, Endpoint[*Ping]{Name: "Ping", Route: RoutePing}, Params{})
+}
+
+// SimplePrice /simple/price Multiple ID and Currency (ids, vs_currencies).
+// With Config.PriceBatch concurrent calls are merged into shared requests,
+// unless ctx carries cache options
+func (c *Client) SimplePrice(ctx context.Context, ids []string, vsCurrencies []string) (data *map[string]map[string]float32, err error) {
+	if err := firstError(requiredList("ids", ids), requiredList("vs_currencies", vsCurrencies)); err != nil {
+		return nil, err
+	}
+	ctx, span := c.startSpan(ctx, "SimplePrice", RouteSimplePrice)
+	defer func() { endSpan(span, err) }()
+	var t map[string]map[string]float32
+	if c.priceBatcher != nil && cacheOptionsFrom(ctx) == (cacheOptions{}) {
+		t, err = c.priceBatcher.do(ctx, ids, vsCurrencies)
+	} else {
+		t, err = c.simplePrice(ctx, ids, vsCurrencies)
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &t, nil
 }
 
+func (c *Client) simplePrice(ctx context.Context, ids []string, vsCurrencies []string) (map[string]map[string]float32, error) {
+	var p Params
+	p.Query.Set("ids", strings.Join(ids, ",")).Set("vs_currencies", strings.Join(vsCurrencies, ","))
+	return get(ctx, c, Endpoint[map[string]map[string]float32]{Name: "SimplePrice", Route: RouteSimplePrice}, p)
+}
+
 // SimpleSinglePrice /simple/price  Single ID and Currency (ids, vs_currency)
-func (c *Client) SimpleSinglePrice(ctx context.Context, id string, vsCurrency string) (*SimpleSinglePrice, error) {
-	idParam := []string{strings.ToLower(id)}
-	vcParam := []string{strings.ToLower(vsCurrency)}
-	t, err := c.SimplePrice(ctx, idParam, vcParam)
+func (c *Client) SimpleSinglePrice(ctx context.Context, id string, vsCurrency string) (data *SimpleSinglePrice, err error) {
+	if err := firstError(required("id", id), required("vs_currency", vsCurrency)); err != nil {
+		return nil, err
+	}
+	ctx, span := c.startSpan(ctx, "SimpleSinglePrice", RouteSimplePrice, coinAttrs(id, vsCurrency)...)
+	defer func() { endSpan(span, err) }()
+	// the API keys the response by the lowercased id and currency
+	idKey, vsKey := strings.ToLower(id), strings.ToLower(vsCurrency)
+	t, err := c.SimplePrice(ctx, []string{idKey}, []string{vsKey})
 	if err != nil {
 		return nil, err
 	}
-	curr := (*t)[id]
-	if len(curr) == 0 {
-		return nil, fmt.Errorf("id or vsCurrency not existed")
+	curr, ok := (*t)[idKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: id %s", ErrNotFound, id)
 	}
-	data := &SimpleSinglePrice{ID: id, Currency: vsCurrency, MarketPrice: curr[vsCurrency]}
+	price, ok := curr[vsKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: vs_currency %s for %s", ErrNotFound, vsCurrency, id)
+	}
+	data = &SimpleSinglePrice{ID: id, Currency: vsCurrency, MarketPrice: price}
 	return data, nil
 }
 
 // SimpleSupportedVSCurrencies /simple/supported_vs_currencies
-func (c *Client) SimpleSupportedVSCurrencies(ctx context.Context) (data *SimpleSupportedVSCurrencies, err error) {
-	err = c.MakeReq(ctx, fmt.Sprintf("%s/simple/supported_vs_currencies", c.cfg.BaseUrl), &data)
-	return
+func (c *Client) SimpleSupportedVSCurrencies(ctx context.Context) (*SimpleSupportedVSCurrencies, error) {
+	e := Endpoint[*SimpleSupportedVSCurrencies]{Name: "SimpleSupportedVSCurrencies", Route: RouteSimpleSupportedVSCurrencies}
+	return Do(ctx, c, e, Params{})
 }
 
 // CoinsList /coins/list
-func (c *Client) CoinsList(ctx context.Context) (data []CoinBaseStruct, err error) {
-	err = c.MakeReq(ctx, fmt.Sprintf("%s/coins/list", c.cfg.BaseUrl), &data)
-	return
+func (c *Client) CoinsList(ctx context.Context) ([]CoinBaseStruct, error) {
+	return Do(ctx, c, Endpoint[[]CoinBaseStruct]{Name: "CoinsList", Route: RouteCoinsList}, Params{})
 }
 
 // CoinsMarket /coins/market
-func (c *Client) CoinsMarket(ctx context.Context, req CoinsMarketRequest) (data []CoinsMarketItem, err error) {
-	if len(req.VsCurrency) == 0 {
-		return nil, fmt.Errorf("vs_currency is required")
-	}
-	params := url.Values{}
-	// vs_currency
-	params.Add("vs_currency", req.VsCurrency)
-	// order
+func (c *Client) CoinsMarket(ctx context.Context, req CoinsMarketRequest) ([]CoinsMarketItem, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
 	if len(req.Order) == 0 {
 		req.Order = OrderTypeObject.MarketCapDesc
 	}
-	params.Add("order", req.Order)
-	// ids
-	if len(req.Ids) != 0 {
-		idsParam := strings.Join(req.Ids[:], ",")
-		params.Add("ids", idsParam)
-	}
-	// per_page
-	if req.PerPage <= 0 || req.PerPage > 250 {
+	if req.PerPage == 0 {
 		req.PerPage = 100
 	}
-	params.Add("per_page", Int2String(req.PerPage))
-	params.Add("page", Int2String(req.Page))
-	// sparkline
-	params.Add("sparkline", Bool2String(req.Sparkline))
-	// price_change_percentage
-	if len(req.PriceChangePercentage) != 0 {
-		priceChangePercentageParam := strings.Join(req.PriceChangePercentage[:], ",")
-		params.Add("price_change_percentage", priceChangePercentageParam)
-	}
-	err = c.MakeReq(ctx, fmt.Sprintf("%s/coins/markets?%s", c.cfg.BaseUrl, params.Encode()), &data)
-	return
+	var p Params
+	p.Query.Set("vs_currency", req.VsCurrency).
+		Set("order", req.Order).
+		List("ids", req.Ids).
+		Int("per_page", req.PerPage).
+		Int("page", req.Page).
+		Bool("sparkline", req.Sparkline).
+		List("price_change_percentage", req.PriceChangePercentage)
+	e := Endpoint[[]CoinsMarketItem]{Name: "CoinsMarket", Route: RouteCoinsMarkets}
+	return Do(ctx, c, e, p, coinAttrs("", req.VsCurrency)...)
 }
 
 type CoinsIDRequest struct {
@@ -184,16 +401,18 @@ type CoinsIDRequest struct {
 }
 
 // CoinsID /coins/{id}
-func (c *Client) CoinsID(ctx context.Context, r CoinsIDRequest) (data *CoinsID, err error) {
-	params := url.Values{}
-	params.Add("localization", Bool2String(r.Localization))
-	params.Add("tickers", Bool2String(r.Tickers))
-	params.Add("market_data", Bool2String(r.MarketData))
-	params.Add("community_data", Bool2String(r.CommunityData))
-	params.Add("developer_data", Bool2String(r.DeveloperData))
-	params.Add("sparkline", Bool2String(r.Sparkline))
-	err = c.MakeReq(ctx, fmt.Sprintf("%s/coins/%s?%s", c.cfg.BaseUrl, r.ID, params.Encode()), &data)
-	return data, nil
+func (c *Client) CoinsID(ctx context.Context, r CoinsIDRequest) (*CoinsID, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	p := Params{Path: map[string]string{"id": r.ID}}
+	p.Query.Bool("localization", r.Localization).
+		Bool("tickers", r.Tickers).
+		Bool("market_data", r.MarketData).
+		Bool("community_data", r.CommunityData).
+		Bool("developer_data", r.DeveloperData).
+		Bool("sparkline", r.Sparkline)
+	return Do(ctx, c, Endpoint[*CoinsID]{Name: "CoinsID", Route: RouteCoinsID}, p, coinAttrs(r.ID, "")...)
 }
 
 type CoinsIDTickersOrder string
@@ -202,6 +421,7 @@ var (
 	TrustScoreDesc CoinsIDTickersOrder = "trust_score_desc"
 	TrustScoreAsc  CoinsIDTickersOrder = "trust_score_asc"
 	VolumeDesc     CoinsIDTickersOrder = "volume_desc"
+	VolumeAsc      CoinsIDTickersOrder = "volume_asc"
 )
 
 type CoinsIDTickersRequest struct {
@@ -213,81 +433,91 @@ type CoinsIDTickersRequest struct {
 }
 
 // CoinsIDTickers /coins/{id}/tickers
-func (c *Client) CoinsIDTickers(ctx context.Context, r CoinsIDTickersRequest) (data *CoinsIDTickers, err error) {
-	params := url.Values{}
-	params.Add("page", strconv.Itoa(r.Page))
-	params.Add("order", string(r.Order))
-	params.Add("depth", Bool2String(r.Depth))
-	//ExchangeIdsParam := strings.Join(r.ExchangeIds[:], ",")
-
-	params.Add("exchange_ids", strings.Join(r.ExchangeIds[:], ","))
-	err = c.MakeReq(ctx, fmt.Sprintf("%s/coins/%s/tickers?%s", c.cfg.BaseUrl, r.ID, params.Encode()), &data)
-	return
+func (c *Client) CoinsIDTickers(ctx context.Context, r CoinsIDTickersRequest) (*CoinsIDTickers, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	p := Params{Path: map[string]string{"id": r.ID}}
+	p.Query.Int("page", r.Page).
+		Opt("order", string(r.Order)).
+		Bool("depth", r.Depth).
+		List("exchange_ids", r.ExchangeIds)
+	e := Endpoint[*CoinsIDTickers]{Name: "CoinsIDTickers", Route: RouteCoinsIDTickers}
+	return Do(ctx, c, e, p, coinAttrs(r.ID, "")...)
 }
 
-// CoinsIDHistory /coins/{id}/history?date={date}&localization=false
-func (c *Client) CoinsIDHistory(ctx context.Context, id string, date string, localization bool) (data *CoinsIDHistory, err error) {
-	if len(id) == 0 || len(date) == 0 {
-		return nil, fmt.Errorf("id and date is required")
+// CoinsIDHistory /coins/{id}/history?date={date}&localization=false, date in HistoryDateLayout
+func (c *Client) CoinsIDHistory(ctx context.Context, id string, date string, localization bool) (*CoinsIDHistory, error) {
+	if err := firstError(required("id", id), validateHistoryDate(date)); err != nil {
+		return nil, err
 	}
-	params := url.Values{}
-	params.Add("date", date)
-	params.Add("localization", Bool2String(localization))
-	err = c.MakeReq(ctx, fmt.Sprintf("%s/coins/%s/history?%s", c.cfg.BaseUrl, id, params.Encode()), &data)
-	return
+	p := Params{Path: map[string]string{"id": id}}
+	p.Query.Set("date", date).Bool("localization", localization)
+	e := Endpoint[*CoinsIDHistory]{Name: "CoinsIDHistory", Route: RouteCoinsIDHistory}
+	return Do(ctx, c, e, p, coinAttrs(id, "")...)
 }
 
 // CoinsIDMarketChart /coins/{id}/market_chart?vs_currency={usd, eur, jpy, etc.}&days={1,14,30,max}
-func (c *Client) CoinsIDMarketChart(ctx context.Context, req CoinsIDMarketChartRequest) (data *CoinsIDMarketChart, err error) {
-	if len(req.ID) == 0 || len(req.VsCurrency) == 0 || len(req.Days) == 0 {
-		return nil, fmt.Errorf("id, vs_currency, and days is required")
+func (c *Client) CoinsIDMarketChart(ctx context.Context, req CoinsIDMarketChartRequest) (*CoinsIDMarketChart, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
 	}
-	params := url.Values{}
-	params.Add("vs_currency", req.VsCurrency)
-	params.Add("days", req.Days)
-	params.Add("interval", req.Interval)
-	err = c.MakeReq(ctx, fmt.Sprintf("%s/coins/%s/market_chart?%s", c.cfg.BaseUrl, req.ID, params.Encode()), &data)
-	return
+	p := Params{Path: map[string]string{"id": req.ID}}
+	p.Query.Set("vs_currency", req.VsCurrency).Set("days", req.Days).Opt("interval", req.Interval)
+	e := Endpoint[*CoinsIDMarketChart]{Name: "CoinsIDMarketChart", Route: RouteCoinsIDMarketChart}
+	return Do(ctx, c, e, p, coinAttrs(req.ID, req.VsCurrency)...)
 }
 
-func (c *Client) CategoriesList(ctx context.Context) (data []CategoriesListItem, err error) {
-	err = c.MakeReq(ctx, fmt.Sprintf("%s/coins/categories/list", c.cfg.BaseUrl), &data)
-	return
+func (c *Client) CategoriesList(ctx context.Context) ([]CategoriesListItem, error) {
+	return Do(ctx, c, Endpoint[[]CategoriesListItem]{Name: "CategoriesList", Route: RouteCategoriesList}, Params{})
 }
-func (c *Client) Categories(ctx context.Context) (data []CategoriesItem, err error) {
-	err = c.MakeReq(ctx, fmt.Sprintf("%s/coins/categories", c.cfg.BaseUrl), &data)
-	return
+func (c *Client) Categories(ctx context.Context) ([]CategoriesItem, error) {
+	return Do(ctx, c, Endpoint[[]CategoriesItem]{Name: "Categories", Route: RouteCategories}, Params{})
 }
-func (c *Client) Exchanges(ctx context.Context, perPage int, page int) (data []ExchangesItem, err error) {
-	params := url.Values{}
-	params.Add("per_page", strconv.Itoa(perPage))
-	params.Add("page", strconv.Itoa(page))
-	err = c.MakeReq(ctx, fmt.Sprintf("%s/exchanges?%s", c.cfg.BaseUrl, params.Encode()), &data)
-	return
+func (c *Client) Exchanges(ctx context.Context, perPage int, page int) ([]ExchangesItem, error) {
+	if err := firstError(nonNegative("per_page", perPage), nonNegative("page", page)); err != nil {
+		return nil, err
+	}
+	var p Params
+	p.Query.Int("per_page", perPage).Int("page", page)
+	return Do(ctx, c, Endpoint[[]ExchangesItem]{Name: "Exchanges", Route: RouteExchanges}, p)
 }
-func (c *Client) ExchangesID(ctx context.Context, id string) (data []ExchangesItem, err error) {
-	err = c.MakeReq(ctx, fmt.Sprintf("%s/exchanges/%s", c.cfg.BaseUrl, id), &data)
-	return
+
+// ExchangesID /exchanges/{id} exchange details with its top 100 tickers
+func (c *Client) ExchangesID(ctx context.Context, id string) (*ExchangeDetail, error) {
+	if err := required("id", id); err != nil {
+		return nil, err
+	}
+	p := Params{Path: map[string]string{"id": id}}
+	return Do(ctx, c, Endpoint[*ExchangeDetail]{Name: "ExchangesID", Route: RouteExchangesID}, p)
 }
 
 // ExchangeRates https://api.coingecko.com/api/v3/exchange_rates
-func (c *Client) ExchangeRates(ctx context.Context) (data *ExchangeRatesItem, err error) {
-	err = c.MakeReq(ctx, fmt.Sprintf("%s/exchange_rates", c.cfg.BaseUrl), &data)
-	return
+func (c *Client) ExchangeRates(ctx context.Context) (*ExchangeRatesItem, error) {
+	resp, err := Do(ctx, c, Endpoint[ExchangeRatesResponse]{Name: "ExchangeRates", Route: RouteExchangeRates}, Params{})
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Rates, nil
 }
 
 // Search https://api.coingecko.com/api/v3/search
-func (c *Client) Search(ctx context.Context, query string) (data *SearchResponse, err error) {
-	params := url.Values{}
-	params.Add("query", query)
-	err = c.MakeReq(ctx, fmt.Sprintf("%s/search?%s", c.cfg.BaseUrl, params.Encode()), &data)
-	return
+func (c *Client) Search(ctx context.Context, query string) (*SearchResponse, error) {
+	if err := required("query", query); err != nil {
+		return nil, err
+	}
+	var p Params
+	p.Query.Set("query", query)
+	return Do(ctx, c, Endpoint[*SearchResponse]{Name: "Search", Route: RouteSearch}, p)
 }
 
 // Global https://api.coingecko.com/api/v3/global
-func (c *Client) Global(ctx context.Context) (data *Global, err error) {
-	err = c.MakeReq(ctx, fmt.Sprintf("%s/global", c.cfg.BaseUrl), &data)
-	return
+func (c *Client) Global(ctx context.Context) (*Global, error) {
+	resp, err := Do(ctx, c, Endpoint[GlobalResponse]{Name: "Global", Route: RouteGlobal}, Params{})
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
 }
 
 // Bool2String boolean to string
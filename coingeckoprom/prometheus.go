@@ -0,0 +1,84 @@
+// Package coingeckoprom exports coingecko.Client instrumentation as Prometheus metrics.
+//
+//	m, err := coingeckoprom.New(prometheus.DefaultRegisterer)
+//	c := coingecko.NewClient(coingecko.Config{Metrics: m})
+package coingeckoprom
+
+import (
+	"github.com/aibotsoft/coingecko"
+	"github.com/prometheus/client_golang/prometheus"
+	"strconv"
+	"time"
+)
+
+// Metrics coingecko.Metrics backed by Prometheus collectors, all labelled by
+// endpoint route template
+type Metrics struct {
+	requests *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+	wait     *prometheus.HistogramVec
+	retries  *prometheus.CounterVec
+	cache    *prometheus.CounterVec
+}
+
+var _ coingecko.Metrics = (*Metrics)(nil)
+
+// New creates the collectors and registers them with reg
+func New(reg prometheus.Registerer) (*Metrics, error) {
+	m := &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "coingecko",
+			Name:      "requests_total",
+			Help:      "Upstream CoinGecko requests by endpoint and HTTP status, status 0 for transport errors.",
+		}, []string{"endpoint", "status"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "coingecko",
+			Name:      "request_duration_seconds",
+			Help:      "Upstream CoinGecko request latency by endpoint.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"endpoint"}),
+		wait: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "coingecko",
+			Name:      "rate_limiter_wait_seconds",
+			Help:      "Time requests waited on the client rate limiter by endpoint.",
+			Buckets:   []float64{0.001, 0.01, 0.05, 0.1, 0.5, 1, 2, 5, 10, 30, 60},
+		}, []string{"endpoint"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "coingecko",
+			Name:      "retries_total",
+			Help:      "Retried CoinGecko requests by endpoint.",
+		}, []string{"endpoint"}),
+		cache: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "coingecko",
+			Name:      "cache_requests_total",
+			Help:      "Cacheable CoinGecko calls by endpoint and result (hit or miss).",
+		}, []string{"endpoint", "result"}),
+	}
+	for _, c := range []prometheus.Collector{m.requests, m.latency, m.wait, m.retries, m.cache} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func (m *Metrics) ObserveRequest(endpoint string, status int, latency time.Duration) {
+	m.requests.WithLabelValues(endpoint, strconv.Itoa(status)).Inc()
+	m.latency.WithLabelValues(endpoint).Observe(latency.Seconds())
+}
+
+func (m *Metrics) ObserveLimiterWait(endpoint string, wait time.Duration) {
+	m.wait.WithLabelValues(endpoint).Observe(wait.Seconds())
+}
+
+func (m *Metrics) IncRetry(endpoint string) {
+	m.retries.WithLabelValues(endpoint).Inc()
+}
+
+func (m *Metrics) IncCacheHit(endpoint string) {
+	m.cache.WithLabelValues(endpoint, "hit").Inc()
+}
+
+func (m *Metrics) IncCacheMiss(endpoint string) {
+	m.cache.WithLabelValues(endpoint, "miss").Inc()
+}
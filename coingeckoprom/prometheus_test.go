@@ -0,0 +1,85 @@
+package coingeckoprom
+
+import (
+	"context"
+	"github.com/aibotsoft/coingecko"
+	"github.com/aibotsoft/coingecko/coingeckotest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"golang.org/x/time/rate"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	srv := coingeckotest.NewServer()
+	defer srv.Close()
+	reg := prometheus.NewPedanticRegistry()
+	m, err := New(reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	retry := coingecko.DefaultRetryPolicy()
+	retry.BaseBackoff = time.Millisecond
+	c := coingecko.NewClient(coingecko.Config{
+		BaseUrl:     srv.URL,
+		RateLimiter: rate.NewLimiter(rate.Inf, 1),
+		Retry:       retry,
+		Cache:       coingecko.NewLRUCache(10),
+		Metrics:     m,
+	})
+	ctx := context.Background()
+	srv.RateLimit("/coins/{id}/market_chart", 1, 0)
+	req := coingecko.CoinsIDMarketChartRequest{ID: "bitcoin", VsCurrency: "usd", Days: "1"}
+	for i := 0; i < 2; i++ {
+		if _, err := c.CoinsIDMarketChart(ctx, req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := `
+# HELP coingecko_cache_requests_total Cacheable CoinGecko calls by endpoint and result (hit or miss).
+# TYPE coingecko_cache_requests_total counter
+coingecko_cache_requests_total{endpoint="/coins/{id}/market_chart",result="hit"} 1
+coingecko_cache_requests_total{endpoint="/coins/{id}/market_chart",result="miss"} 1
+# HELP coingecko_requests_total Upstream CoinGecko requests by endpoint and HTTP status, status 0 for transport errors.
+# TYPE coingecko_requests_total counter
+coingecko_requests_total{endpoint="/coins/{id}/market_chart",status="200"} 1
+coingecko_requests_total{endpoint="/coins/{id}/market_chart",status="429"} 1
+# HELP coingecko_retries_total Retried CoinGecko requests by endpoint.
+# TYPE coingecko_retries_total counter
+coingecko_retries_total{endpoint="/coins/{id}/market_chart"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"coingecko_cache_requests_total", "coingecko_requests_total", "coingecko_retries_total"); err != nil {
+		t.Error(err)
+	}
+	if n := testutil.CollectAndCount(m.latency); n != 1 {
+		t.Errorf("latency series = %d, want 1", n)
+	}
+	if n := testutil.CollectAndCount(m.wait); n != 1 {
+		t.Errorf("limiter wait series = %d, want 1", n)
+	}
+}
+
+func TestMetricsOtherRoute(t *testing.T) {
+	srv := coingeckotest.NewServer()
+	defer srv.Close()
+	reg := prometheus.NewPedanticRegistry()
+	m, err := New(reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := coingecko.NewClient(coingecko.Config{BaseUrl: srv.URL, RateLimiter: rate.NewLimiter(rate.Inf, 1), Metrics: m})
+	for _, path := range []string{"/unknown", "/coins/bitcoin/unknown/a", "/coins/bitcoin/unknown/b"} {
+		var v interface{}
+		_ = c.MakeReq(context.Background(), srv.URL+path, &v)
+	}
+	if n := testutil.CollectAndCount(m.requests); n != 1 {
+		t.Errorf("request series = %d, want 1", n)
+	}
+	if got := testutil.ToFloat64(m.requests.WithLabelValues(coingecko.RouteOther, "404")); got != 3 {
+		t.Errorf("other requests = %v, want 3", got)
+	}
+}
@@ -0,0 +1,79 @@
+package coingeckotest
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	rec, err := NewRecorder(path, ModeRecord, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: rec}
+	get := func(c *http.Client, u string) (int, string, error) {
+		req, _ := http.NewRequest("GET", u, nil)
+		req.Header.Set("x-cg-pro-api-key", "secret-key")
+		resp, err := c.Do(req)
+		if err != nil {
+			return 0, "", err
+		}
+		defer resp.Body.Close()
+		body, err := ioutil.ReadAll(resp.Body)
+		return resp.StatusCode, string(body), err
+	}
+	srv.RateLimit("/ping", 1, 0)
+	for _, u := range []string{
+		"/ping",
+		"/ping",
+		"/simple/price?vs_currencies=usd&ids=bitcoin&x_cg_pro_api_key=secret-key",
+	} {
+		if _, _, err := get(client, srv.URL+u); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret-key") {
+		t.Errorf("cassette contains API key:\n%s", data)
+	}
+
+	replay, err := NewRecorder(path, ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Close()
+	client = &http.Client{Transport: replay}
+	tests := []struct {
+		url    string
+		status int
+		body   string
+	}{
+		{"/ping", 429, "Rate Limit"},
+		{"/ping", 200, "To the Moon"},
+		{"/ping", 200, "To the Moon"},
+		{"/simple/price?ids=bitcoin&vs_currencies=usd", 200, "5013.61"},
+	}
+	for _, tt := range tests {
+		status, body, err := get(client, srv.URL+tt.url)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.url, err)
+		}
+		if status != tt.status || !strings.Contains(body, tt.body) {
+			t.Errorf("%s: got %d %s", tt.url, status, body)
+		}
+	}
+	if _, _, err := get(client, srv.URL+"/coins/list"); !errors.Is(err, ErrNoInteraction) {
+		t.Errorf("got %v, want ErrNoInteraction", err)
+	}
+}
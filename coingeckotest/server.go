@@ -0,0 +1,355 @@
+// Package coingeckotest provides an offline fake of the CoinGecko API for tests.
+//
+//	srv := coingeckotest.NewServer()
+//	defer srv.Close()
+//	c := coingecko.NewClient(coingecko.Config{BaseUrl: srv.URL})
+//
+// Every endpoint supported by the client is served from embedded fixtures,
+// both at the root and under /api/v3.
+// Responses, errors, rate limits and latency can be programmed per route and
+// the requests received by each route can be inspected afterwards.
+package coingeckotest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// fixtureFiles maps route templates to the fixture served by default
+var fixtureFiles = map[string]string{
+	"/ping":                                   "ping.json",
+	"/simple/price":                           "simple_price.json",
+	"/simple/supported_vs_currencies":         "simple_supported_vs_currencies.json",
+	"/simple/token_price/{id}":                "simple_token_price.json",
+	"/coins/list":                             "coins_list.json",
+	"/coins/markets":                          "coins_markets.json",
+	"/coins/{id}":                             "coins_id.json",
+	"/coins/{id}/tickers":                     "coins_id_tickers.json",
+	"/coins/{id}/history":                     "coins_id_history.json",
+	"/coins/{id}/market_chart":                "coins_id_market_chart.json",
+	"/coins/{id}/market_chart/range":          "coins_id_market_chart_range.json",
+	"/coins/{id}/ohlc":                        "coins_id_ohlc.json",
+	"/coins/{id}/ohlc/range":                  "coins_id_ohlc_range.json",
+	"/coins/{id}/contract/{contract_address}": "coins_contract.json",
+	"/coins/{id}/contract/{contract_address}/market_chart":       "coins_id_market_chart.json",
+	"/coins/{id}/contract/{contract_address}/market_chart/range": "coins_id_market_chart_range.json",
+	"/asset_platforms":                   "asset_platforms.json",
+	"/coins/categories/list":             "coins_categories_list.json",
+	"/coins/categories":                  "coins_categories.json",
+	"/exchanges":                         "exchanges.json",
+	"/exchanges/{id}/tickers":            "exchanges_id_tickers.json",
+	"/exchanges/list":                    "exchanges_list.json",
+	"/exchanges/{id}/volume_chart":       "exchanges_id_volume_chart.json",
+	"/exchanges/{id}/volume_chart/range": "exchanges_id_volume_chart.json",
+	"/exchanges/{id}":                    "exchanges_id.json",
+	"/derivatives":                       "derivatives.json",
+	"/derivatives/exchanges":             "derivatives_exchanges.json",
+	"/derivatives/exchanges/{id}":        "derivatives_exchanges_id.json",
+	"/derivatives/exchanges/list":        "derivatives_exchanges_list.json",
+	"/indexes":                           "indexes.json",
+	"/indexes/{market_id}/{id}":          "indexes_market_id.json",
+	"/indexes/list":                      "indexes_list.json",
+	"/nfts/list":                         "nfts_list.json",
+	"/nfts/{id}":                         "nfts_id.json",
+	"/nfts/{asset_platform_id}/contract/{contract_address}": "nfts_id.json",
+	"/exchange_rates": "exchange_rates.json",
+	"/search":         "search.json",
+	"/global":         "global.json",
+}
+
+// dynamicRoutes build the default response from the fixture and the request
+var dynamicRoutes = map[string]func(fixture []byte, r *http.Request) ([]byte, error){
+	"/simple/price":            filterPrices("ids"),
+	"/simple/token_price/{id}": filterTokenPrices,
+}
+
+// Response programmed response for a route
+type Response struct {
+	// Status HTTP status, default 200
+	Status int
+	// Body raw response body, the route fixture is used when empty and Status is 200
+	Body string
+	// Header extra response headers
+	Header http.Header
+	// Latency delay before the response is written
+	Latency time.Duration
+	// Times how many requests the response is used for, 0 means until Reset
+	Times int
+}
+
+// Request request received by the server
+type Request struct {
+	// Route matched route template, e.g. /coins/{id}/market_chart
+	Route string
+	// Path request path
+	Path string
+	// Query decoded query parameters
+	Query url.Values
+	// Header request headers
+	Header http.Header
+}
+
+// Server fake CoinGecko API server
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	responses map[string][]*Response
+	latency   map[string]time.Duration
+	requests  []Request
+}
+
+// NewServer starts a fake CoinGecko server, call Close when done
+func NewServer() *Server {
+	s := &Server{}
+	s.Reset()
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Routes route templates served by default
+func Routes() []string {
+	routes := make([]string, 0, len(fixtureFiles))
+	for r := range fixtureFiles {
+		routes = append(routes, r)
+	}
+	return routes
+}
+
+// Reset drops programmed responses, latency and recorded requests
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses = make(map[string][]*Response)
+	s.latency = make(map[string]time.Duration)
+	s.requests = nil
+}
+
+// Respond queues r for route. Queued responses are used in order, a response
+// with Times 0 stays in place until Reset.
+func (s *Server) Respond(route string, r Response) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[route] = append(s.responses[route], &r)
+}
+
+// Error makes the next times requests to route fail with a CoinGecko error payload
+func (s *Server) Error(route string, status int, code int, message string, times int) {
+	body, _ := json.Marshal(map[string]interface{}{
+		"status": map[string]interface{}{"error_code": code, "error_message": message},
+	})
+	s.Respond(route, Response{Status: status, Body: string(body), Times: times})
+}
+
+// RateLimit makes the next times requests to route fail with 429 and Retry-After
+func (s *Server) RateLimit(route string, times int, retryAfter time.Duration) {
+	body := `{"status":{"error_code":429,"error_message":"You've exceeded the Rate Limit. Please visit https://www.coingecko.com/en/api/pricing to subscribe to our API plans for higher rate limits."}}`
+	h := http.Header{}
+	h.Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+	s.Respond(route, Response{Status: http.StatusTooManyRequests, Body: body, Header: h, Times: times})
+}
+
+// Latency delays every response of route by d
+func (s *Server) Latency(route string, d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency[route] = d
+}
+
+// Requests requests received for route, all requests when route is empty
+func (s *Server) Requests(route string) []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []Request
+	for _, r := range s.requests {
+		if route == "" || r.Route == route {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// LastQuery query parameters of the last request to route, nil if there was none
+func (s *Server) LastQuery(route string) url.Values {
+	reqs := s.Requests(route)
+	if len(reqs) == 0 {
+		return nil
+	}
+	return reqs[len(reqs)-1].Query
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	route := matchRoute(strings.TrimPrefix(r.URL.Path, "/api/v3"))
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Route: route, Path: r.URL.Path, Query: r.URL.Query(), Header: r.Header.Clone()})
+	resp := s.next(route)
+	latency := s.latency[route]
+	s.mu.Unlock()
+
+	if resp != nil {
+		latency += resp.Latency
+	}
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if resp != nil {
+		for k, v := range resp.Header {
+			w.Header()[k] = v
+		}
+		status := resp.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		if resp.Body != "" || status != http.StatusOK {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(resp.Body))
+			return
+		}
+	}
+
+	body, err := fixture(route, r)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprintf(w, `{"error":%q}`, err.Error())
+		return
+	}
+	_, _ = w.Write(body)
+}
+
+// next pops the programmed response for route, s.mu must be held
+func (s *Server) next(route string) *Response {
+	queue := s.responses[route]
+	if len(queue) == 0 {
+		return nil
+	}
+	resp := queue[0]
+	if resp.Times > 0 {
+		resp.Times--
+		if resp.Times == 0 {
+			s.responses[route] = queue[1:]
+		}
+	}
+	return resp
+}
+
+// fixture default response body for route
+func fixture(route string, r *http.Request) ([]byte, error) {
+	name, ok := fixtureFiles[route]
+	if !ok {
+		return nil, fmt.Errorf("unknown route %s", r.URL.Path)
+	}
+	body, err := fixtures.ReadFile("fixtures/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if fn, ok := dynamicRoutes[route]; ok {
+		return fn(body, r)
+	}
+	return body, nil
+}
+
+// filterPrices keeps only the requested keys and vs_currencies of a price table fixture
+func filterPrices(keyParam string) func(fixture []byte, r *http.Request) ([]byte, error) {
+	return func(fixture []byte, r *http.Request) ([]byte, error) {
+		var table map[string]map[string]float64
+		if err := json.Unmarshal(fixture, &table); err != nil {
+			return nil, err
+		}
+		q := r.URL.Query()
+		res := make(map[string]map[string]float64)
+		for _, key := range splitList(q.Get(keyParam)) {
+			prices, ok := table[strings.ToLower(key)]
+			if !ok {
+				continue
+			}
+			res[key] = make(map[string]float64)
+			for _, vs := range splitList(q.Get("vs_currencies")) {
+				if p, ok := prices[vs]; ok {
+					res[key][vs] = p
+				}
+			}
+		}
+		return json.Marshal(res)
+	}
+}
+
+// filterTokenPrices serves the requested contract_addresses of the platform in
+// the path, matching EVM addresses case insensitively and answering them in
+// lower case like the live API
+func filterTokenPrices(fixture []byte, r *http.Request) ([]byte, error) {
+	var platforms map[string]map[string]map[string]float64
+	if err := json.Unmarshal(fixture, &platforms); err != nil {
+		return nil, err
+	}
+	table := platforms[path.Base(r.URL.Path)]
+	q := r.URL.Query()
+	res := make(map[string]map[string]float64)
+	for _, address := range splitList(q.Get("contract_addresses")) {
+		prices, ok := table[address]
+		if !ok {
+			address = strings.ToLower(address)
+			if prices, ok = table[address]; !ok {
+				continue
+			}
+		}
+		res[address] = make(map[string]float64)
+		for _, vs := range splitList(q.Get("vs_currencies")) {
+			if p, ok := prices[vs]; ok {
+				res[address][vs] = p
+			}
+		}
+	}
+	return json.Marshal(res)
+}
+
+func splitList(v string) []string {
+	if v == "" {
+		return nil
+	}
+	return strings.Split(v, ",")
+}
+
+// matchRoute returns the route template for path, literal segments win over placeholders
+func matchRoute(path string) string {
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	best, bestScore := path, -1
+	for r := range fixtureFiles {
+		rsegs := strings.Split(strings.Trim(r, "/"), "/")
+		if len(rsegs) != len(segs) {
+			continue
+		}
+		score := 0
+		for i, seg := range rsegs {
+			if strings.HasPrefix(seg, "{") {
+				continue
+			}
+			if seg != segs[i] {
+				score = -1
+				break
+			}
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = r, score
+		}
+	}
+	return best
+}
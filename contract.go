@@ -0,0 +1,65 @@
+package coingecko
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// contractParams path of the contract routes, the address normalized for the chain
+func contractParams(platform string, address string) Params {
+	return Params{Path: map[string]string{"id": platform, "contract_address": normalizeAddress(platform, address)}}
+}
+
+// CoinsIDContractAddress /coins/{id}/contract/{contract_address} coin data of the token
+// with the contract address on the asset platform, e.g. ethereum
+func (c *Client) CoinsIDContractAddress(ctx context.Context, platform string, address string) (*CoinsIDContractAddress, error) {
+	if err := firstError(required("asset_platform_id", platform), required("contract_address", address)); err != nil {
+		return nil, err
+	}
+	e := Endpoint[*CoinsIDContractAddress]{Name: "CoinsIDContractAddress", Route: RouteCoinsIDContractAddress}
+	return Do(ctx, c, e, contractParams(platform, address))
+}
+
+// CoinsIDContractMarketChart /coins/{id}/contract/{contract_address}/market_chart
+// prices, market caps and volumes of the token over the last days, a number or max
+func (c *Client) CoinsIDContractMarketChart(ctx context.Context, platform string, address string, vsCurrency string, days string) (*CoinsIDMarketChart, error) {
+	err := firstError(
+		required("asset_platform_id", platform),
+		required("contract_address", address),
+		required("vs_currency", vsCurrency),
+		validateDays(days),
+	)
+	if err != nil {
+		return nil, err
+	}
+	p := contractParams(platform, address)
+	p.Query.Set("vs_currency", vsCurrency).Set("days", days)
+	e := Endpoint[*CoinsIDMarketChart]{Name: "CoinsIDContractMarketChart", Route: RouteCoinsIDContractMarketChart}
+	return Do(ctx, c, e, p, coinAttrs("", vsCurrency)...)
+}
+
+// CoinsIDContractMarketChartRange /coins/{id}/contract/{contract_address}/market_chart/range
+// prices, market caps and volumes of the token between from and to
+func (c *Client) CoinsIDContractMarketChartRange(ctx context.Context, platform string, address string, vsCurrency string,
+	from time.Time, to time.Time) (*MarketChartRange, error) {
+	err := firstError(
+		required("asset_platform_id", platform),
+		required("contract_address", address),
+		required("vs_currency", vsCurrency),
+		validateRange(from, to),
+	)
+	if err != nil {
+		return nil, err
+	}
+	p := contractParams(platform, address)
+	p.Query.Set("vs_currency", vsCurrency).
+		Set("from", strconv.FormatInt(from.Unix(), 10)).
+		Set("to", strconv.FormatInt(to.Unix(), 10))
+	e := Endpoint[CoinsIDMarketChart]{Name: "CoinsIDContractMarketChartRange", Route: RouteCoinsIDContractMarketChartRange}
+	data, err := Do(ctx, c, e, p, coinAttrs("", vsCurrency)...)
+	if err != nil {
+		return nil, err
+	}
+	return &MarketChartRange{CoinsIDMarketChart: data, Granularity: rangeGranularity(from, to, time.Now())}, nil
+}
@@ -0,0 +1,65 @@
+package coingecko
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const usdcAddress = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+
+func TestCoinsIDContractAddress(t *testing.T) {
+	defer srv.Reset()
+	got, err := c.CoinsIDContractAddress(context.Background(), "ethereum", usdcAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != "usd-coin" || got.AssetPlatformID != "ethereum" || got.ContractAddress != strings.ToLower(usdcAddress) ||
+		got.Platforms["polygon-pos"] == "" || *got.DetailPlatforms["solana"].DecimalPlace != 6 || got.MarketData.MarketCapRank != 7 {
+		t.Errorf("got %+v", got)
+	}
+	reqs := srv.Requests(RouteCoinsIDContractAddress)
+	if len(reqs) != 1 || !strings.HasSuffix(reqs[0].Path, "/coins/ethereum/contract/"+strings.ToLower(usdcAddress)) {
+		t.Errorf("requests %+v", reqs)
+	}
+	if _, err := c.CoinsIDContractAddress(context.Background(), "ethereum", ""); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("missing address: got %v", err)
+	}
+	_, err = c.CoinsIDContractAddress(context.Background(), "", usdcAddress)
+	var invalid *InvalidArgumentError
+	if !errors.As(err, &invalid) || invalid.Field != "asset_platform_id" {
+		t.Errorf("missing platform: got %v", err)
+	}
+}
+
+func TestCoinsIDContractMarketChart(t *testing.T) {
+	defer srv.Reset()
+	ctx := context.Background()
+	chart, err := c.CoinsIDContractMarketChart(ctx, "ethereum", usdcAddress, "usd", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chart.Prices) != 3 {
+		t.Errorf("got %+v", chart)
+	}
+	if q := srv.LastQuery(RouteCoinsIDContractMarketChart); q.Get("days") != "1" || q.Get("vs_currency") != "usd" {
+		t.Errorf("query = %v", q)
+	}
+
+	from := time.Date(2023, 3, 10, 0, 0, 0, 0, time.UTC)
+	ranged, err := c.CoinsIDContractMarketChartRange(ctx, "ethereum", usdcAddress, "usd", from, from.AddDate(0, 0, 120))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ranged.Prices) != 5 || ranged.Granularity != GranularityDaily {
+		t.Errorf("got %+v", ranged)
+	}
+	if q := srv.LastQuery(RouteCoinsIDContractMarketChartRange); q.Get("from") != "1678406400" {
+		t.Errorf("query = %v", q)
+	}
+	if _, err := c.CoinsIDContractMarketChartRange(ctx, "ethereum", usdcAddress, "usd", from, from); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("empty range: got %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package coingecko
+
+import (
+	"context"
+)
+
+// DerivativesExchangesOrder sort order of DerivativesExchanges
+type DerivativesExchangesOrder string
+
+const (
+	DerivativesNameAsc               DerivativesExchangesOrder = "name_asc"
+	DerivativesNameDesc              DerivativesExchangesOrder = "name_desc"
+	DerivativesOpenInterestBtcAsc    DerivativesExchangesOrder = "open_interest_btc_asc"
+	DerivativesOpenInterestBtcDesc   DerivativesExchangesOrder = "open_interest_btc_desc"
+	DerivativesTradeVolume24hBtcAsc  DerivativesExchangesOrder = "trade_volume_24h_btc_asc"
+	DerivativesTradeVolume24hBtcDesc DerivativesExchangesOrder = "trade_volume_24h_btc_desc"
+)
+
+// DerivativeTickersFilter tickers included by DerivativesExchangesID
+type DerivativeTickersFilter string
+
+const (
+	DerivativeTickersAll       DerivativeTickersFilter = "all"
+	DerivativeTickersUnexpired DerivativeTickersFilter = "unexpired"
+)
+
+// Derivatives /derivatives tickers of every derivatives market
+func (c *Client) Derivatives(ctx context.Context) ([]DerivativeTicker, error) {
+	return Do(ctx, c, Endpoint[[]DerivativeTicker]{Name: "Derivatives", Route: RouteDerivatives}, Params{})
+}
+
+// DerivativesExchanges /derivatives/exchanges, the API defaults apply to an empty order and zero perPage or page
+func (c *Client) DerivativesExchanges(ctx context.Context, order DerivativesExchangesOrder, perPage int, page int) ([]DerivativesExchange, error) {
+	err := firstError(
+		oneOf("order", string(order), string(DerivativesNameAsc), string(DerivativesNameDesc),
+			string(DerivativesOpenInterestBtcAsc), string(DerivativesOpenInterestBtcDesc),
+			string(DerivativesTradeVolume24hBtcAsc), string(DerivativesTradeVolume24hBtcDesc)),
+		nonNegative("per_page", perPage),
+		nonNegative("page", page),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var p Params
+	p.Query.Opt("order", string(order)).Int("per_page", perPage).Int("page", page)
+	e := Endpoint[[]DerivativesExchange]{Name: "DerivativesExchanges", Route: RouteDerivativesExchanges}
+	return Do(ctx, c, e, p)
+}
+
+// DerivativesExchangesID /derivatives/exchanges/{id}, tickers are only included when tickers is set
+func (c *Client) DerivativesExchangesID(ctx context.Context, id string, tickers DerivativeTickersFilter) (*DerivativesExchangeDetail, error) {
+	err := firstError(
+		required("id", id),
+		oneOf("include_tickers", string(tickers), string(DerivativeTickersAll), string(DerivativeTickersUnexpired)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	p := Params{Path: map[string]string{"id": id}}
+	p.Query.Opt("include_tickers", string(tickers))
+	e := Endpoint[*DerivativesExchangeDetail]{Name: "DerivativesExchangesID", Route: RouteDerivativesExchangesID}
+	return Do(ctx, c, e, p)
+}
+
+// DerivativesExchangesList /derivatives/exchanges/list id and name of every derivatives exchange
+func (c *Client) DerivativesExchangesList(ctx context.Context) ([]DerivativesExchangeListItem, error) {
+	e := Endpoint[[]DerivativesExchangeListItem]{Name: "DerivativesExchangesList", Route: RouteDerivativesExchangesList}
+	return Do(ctx, c, e, Params{})
+}
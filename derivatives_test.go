@@ -0,0 +1,96 @@
+package coingecko
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFlexFloat(t *testing.T) {
+	tests := []struct {
+		in   string
+		want FlexFloat
+	}{
+		{`1.5`, 1.5},
+		{`"1.5"`, 1.5},
+		{`"-2e-3"`, -0.002},
+		{`""`, 0},
+		{`null`, 0},
+	}
+	for _, tt := range tests {
+		var f FlexFloat
+		if err := f.UnmarshalJSON([]byte(tt.in)); err != nil || f != tt.want {
+			t.Errorf("%s: got %v, %v, want %v", tt.in, f, err, tt.want)
+		}
+	}
+	var f FlexFloat
+	if err := f.UnmarshalJSON([]byte(`"n/a"`)); err == nil {
+		t.Error("want error for a non numeric string")
+	}
+}
+
+func TestDerivatives(t *testing.T) {
+	defer srv.Reset()
+	got, err := c.Derivatives(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %+v", got)
+	}
+	perp, fut := got[0], got[1]
+	if perp.Price != 69434.1 || perp.FundingRate != 0.012 || perp.OpenInterest != 7690212057.6 ||
+		!perp.LastTradedAt.Equal(time.Unix(1712467658, 0)) || !perp.ExpiredAt.IsZero() {
+		t.Errorf("perpetual %+v", perp)
+	}
+	if fut.Index != 69410.2 || fut.Basis != 6.83 || fut.Spread != 0 || fut.FundingRate != 0 || fut.Volume24h != 21234567.5 ||
+		!fut.ExpiredAt.Equal(time.Date(2024, 12, 27, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("futures %+v", fut)
+	}
+}
+
+func TestDerivativesExchanges(t *testing.T) {
+	defer srv.Reset()
+	ctx := context.Background()
+	got, err := c.DerivativesExchanges(ctx, DerivativesOpenInterestBtcDesc, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].TradeVolume24hBtc != 574366.94 || *got[0].YearEstablished != 2019 || got[1].YearEstablished != nil {
+		t.Errorf("got %+v", got)
+	}
+	q := srv.LastQuery(RouteDerivativesExchanges)
+	if q.Get("order") != "open_interest_btc_desc" || q.Get("per_page") != "2" || q.Get("page") != "1" {
+		t.Errorf("query = %v", q)
+	}
+	if _, err := c.DerivativesExchanges(ctx, "volume_desc", 0, 0); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("unknown order: got %v", err)
+	}
+
+	detail, err := c.DerivativesExchangesID(ctx, "binance_futures", DerivativeTickersUnexpired)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if detail.Name != "Binance (Futures)" || len(detail.Tickers) != 1 || detail.Tickers[0].FundingRate != 0.005 ||
+		detail.Tickers[0].ConvertedVolume.USD < 61648664 || detail.Tickers[0].LastTraded.Unix() != 1712550723 {
+		t.Errorf("detail %+v", detail)
+	}
+	if q := srv.LastQuery(RouteDerivativesExchangesID); q.Get("include_tickers") != "unexpired" {
+		t.Errorf("query = %v", q)
+	}
+	if _, err := c.DerivativesExchangesID(ctx, "binance_futures", ""); err != nil {
+		t.Fatal(err)
+	}
+	if q := srv.LastQuery(RouteDerivativesExchangesID); q.Has("include_tickers") {
+		t.Errorf("query = %v", q)
+	}
+
+	list, err := c.DerivativesExchangesList(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 3 || list[2].ID != "deribit" {
+		t.Errorf("list %+v", list)
+	}
+}
@@ -0,0 +1,114 @@
+package coingecko
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Endpoint describes a CoinGecko API endpoint whose response decodes into T.
+// Endpoints not covered by the client can be called through Do with their own descriptor.
+type Endpoint[T any] struct {
+	// Name of the call used for spans, e.g. CoinsIDMarketChart
+	Name string
+	// Route path template relative to the base URL, e.g. /coins/{id}/market_chart
+	Route string
+}
+
+// Params path and query parameters of a single call
+type Params struct {
+	// Path values of the route placeholders, e.g. {"id": "bitcoin"}, escaped when the URL is built
+	Path map[string]string
+	// Query parameters
+	Query Query
+}
+
+// Query builds the query string of a call, optional parameters with empty values are dropped
+type Query struct {
+	values url.Values
+}
+
+// Set adds a required parameter, sent even when value is empty
+func (q *Query) Set(key string, value string) *Query {
+	if q.values == nil {
+		q.values = url.Values{}
+	}
+	q.values.Set(key, value)
+	return q
+}
+
+// Opt adds an optional parameter, dropped when value is empty
+func (q *Query) Opt(key string, value string) *Query {
+	if value == "" {
+		return q
+	}
+	return q.Set(key, value)
+}
+
+// List adds an optional comma separated list, dropped when values is empty
+func (q *Query) List(key string, values []string) *Query {
+	return q.Opt(key, strings.Join(values, ","))
+}
+
+// Int adds an optional integer, dropped when i is 0
+func (q *Query) Int(key string, i int) *Query {
+	if i == 0 {
+		return q
+	}
+	return q.Set(key, strconv.Itoa(i))
+}
+
+// Bool adds a boolean, always sent so the API default never applies silently
+func (q *Query) Bool(key string, b bool) *Query {
+	return q.Set(key, strconv.FormatBool(b))
+}
+
+// Encode query string sorted by key
+func (q *Query) Encode() string {
+	return q.values.Encode()
+}
+
+// Do calls endpoint e on c and decodes the response into T, inside a span
+// named after the endpoint carrying attrs. It goes through the same cache,
+// coalescing, retry and rate limiting as the built-in methods.
+func Do[T any](ctx context.Context, c *Client, e Endpoint[T], p Params, attrs ...attribute.KeyValue) (data T, err error) {
+	ctx, span := c.startSpan(ctx, e.Name, e.Route, attrs...)
+	defer func() { endSpan(span, err) }()
+	return get(ctx, c, e, p)
+}
+
+// get calls e without opening a span of its own
+func get[T any](ctx context.Context, c *Client, e Endpoint[T], p Params) (data T, err error) {
+	u, err := c.endpointURL(e.Route, p)
+	if err != nil {
+		return data, err
+	}
+	if err = c.makeReq(ctx, e.Route, u, &data); err != nil {
+		var zero T
+		return zero, err
+	}
+	return data, nil
+}
+
+// endpointURL full URL of route with escaped path values and the encoded query
+func (c *Client) endpointURL(route string, p Params) (string, error) {
+	segs := strings.Split(route, "/")
+	for i, s := range segs {
+		if !strings.HasPrefix(s, "{") || !strings.HasSuffix(s, "}") {
+			continue
+		}
+		name := s[1 : len(s)-1]
+		v := p.Path[name]
+		if v == "" {
+			return "", invalid(name, "is required")
+		}
+		segs[i] = url.PathEscape(v)
+	}
+	u := c.cfg.BaseUrl + strings.Join(segs, "/")
+	if q := p.Query.Encode(); q != "" {
+		u += "?" + q
+	}
+	return u, nil
+}
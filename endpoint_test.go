@@ -0,0 +1,68 @@
+package coingecko
+
+import (
+	"context"
+	"golang.org/x/time/rate"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestQuery(t *testing.T) {
+	var q Query
+	q.Set("vs_currency", "usd").
+		Opt("order", "").
+		List("ids", nil).
+		List("price_change_percentage", []string{"1h", "24h"}).
+		Int("page", 0).
+		Int("per_page", 50).
+		Bool("sparkline", false)
+	want := "per_page=50&price_change_percentage=1h%2C24h&sparkline=false&vs_currency=usd"
+	if got := q.Encode(); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestEndpointURL(t *testing.T) {
+	c := NewClient(Config{BaseUrl: "http://localhost/api/v3"})
+	u, err := c.endpointURL(RouteCoinsIDTickers, Params{Path: map[string]string{"id": "a/b c?"}})
+	if err != nil || u != "http://localhost/api/v3/coins/a%2Fb%20c%3F/tickers" {
+		t.Errorf("got %s, %v", u, err)
+	}
+	if _, err := c.endpointURL(RouteCoinsIDTickers, Params{}); err == nil || !strings.Contains(err.Error(), "id") {
+		t.Errorf("missing path parameter: got %v", err)
+	}
+}
+
+func TestDoCustomEndpoint(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.EscapedPath() + "?" + r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"id":"bitcoin","platforms":{"ethereum":"0xabc"}}`))
+	}))
+	defer srv.Close()
+	logger, records := newTestLogger(slog.LevelDebug)
+	c := NewClient(Config{BaseUrl: srv.URL, RateLimiter: rate.NewLimiter(rate.Inf, 1), Logger: logger})
+
+	type coin struct {
+		ID        string            `json:"id"`
+		Platforms map[string]string `json:"platforms"`
+	}
+	p := Params{Path: map[string]string{"id": "wrapped/bitcoin"}}
+	p.Query.Bool("localization", false).Opt("tickers", "")
+	data, err := Do(context.Background(), c, Endpoint[coin]{Name: "CoinPlatforms", Route: "/coins/{id}/platforms"}, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.ID != "bitcoin" || data.Platforms["ethereum"] != "0xabc" {
+		t.Errorf("got %+v", data)
+	}
+	if path != "/coins/wrapped%2Fbitcoin/platforms?localization=false" {
+		t.Errorf("request %s", path)
+	}
+	if got := records(); len(got) != 1 || got[0]["endpoint"] != "/coins/{id}/platforms" {
+		t.Errorf("log records %v", got)
+	}
+}
@@ -0,0 +1,196 @@
+package coingecko
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sentinel errors matched by *APIError through errors.Is
+var (
+	ErrRateLimited    = errors.New("coingecko: rate limited")
+	ErrNotFound       = errors.New("coingecko: not found")
+	ErrUnauthorized   = errors.New("coingecko: unauthorized")
+	ErrPlanRestricted = errors.New("coingecko: endpoint not available on current plan")
+	ErrServer         = errors.New("coingecko: server error")
+	// ErrResponseTooLarge matched by *ResponseTooLargeError
+	ErrResponseTooLarge = errors.New("coingecko: response too large")
+	// ErrInvalidArgument matched by *InvalidArgumentError
+	ErrInvalidArgument = errors.New("coingecko: invalid argument")
+)
+
+// InvalidArgumentError is returned before any request is sent when an argument is rejected
+type InvalidArgumentError struct {
+	// Field is the API parameter name of the argument, e.g. vs_currency
+	Field string
+	// Reason why the value was rejected
+	Reason string
+}
+
+func (e *InvalidArgumentError) Error() string {
+	return fmt.Sprintf("coingecko: invalid argument %s: %s", e.Field, e.Reason)
+}
+
+// Is reports whether target is ErrInvalidArgument
+func (e *InvalidArgumentError) Is(target error) bool {
+	return target == ErrInvalidArgument
+}
+
+// ResponseTooLargeError is returned when a response body exceeds Config.MaxResponseSize
+type ResponseTooLargeError struct {
+	// Limit is the max response size in bytes
+	Limit int64
+	// URL is the request URL with API keys removed
+	URL string
+}
+
+func (e *ResponseTooLargeError) Error() string {
+	return fmt.Sprintf("coingecko: response of %s exceeds %d bytes", e.URL, e.Limit)
+}
+
+// Is reports whether target is ErrResponseTooLarge
+func (e *ResponseTooLargeError) Is(target error) bool {
+	return target == ErrResponseTooLarge
+}
+
+// CoinGecko error codes sent in the status.error_code field
+const (
+	errorCodeRateLimited      = 429
+	errorCodeAPIKeyMissing    = 10002
+	errorCodePlanRestricted   = 10005
+	errorCodeInvalidProKey    = 10010
+	errorCodeInvalidDemoKey   = 10011
+	errorCodeTimeRangeExceeds = 10012
+)
+
+// APIError is returned by MakeReq when CoinGecko answers with a non 200 status
+type APIError struct {
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+	// Code is the CoinGecko error_code from the response payload, 0 if absent
+	Code int
+	// Message is the CoinGecko error message, or the raw body when it is not JSON
+	Message string
+	// RetryAfter is the parsed Retry-After header, 0 if absent
+	RetryAfter time.Duration
+	// URL is the request URL with API keys removed
+	URL string
+	// Body is the raw response body
+	Body []byte
+}
+
+func (e *APIError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.Code != 0 && e.Code != e.StatusCode {
+		return fmt.Sprintf("coingecko: %d (code %d): %s", e.StatusCode, e.Code, msg)
+	}
+	return fmt.Sprintf("coingecko: %d: %s", e.StatusCode, msg)
+}
+
+// Is reports whether the error matches one of the sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests || e.Code == errorCodeRateLimited
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		switch e.Code {
+		case errorCodeAPIKeyMissing, errorCodeInvalidProKey, errorCodeInvalidDemoKey:
+			return true
+		case errorCodePlanRestricted, errorCodeTimeRangeExceeds:
+			return false
+		}
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrPlanRestricted:
+		return e.Code == errorCodePlanRestricted || e.Code == errorCodeTimeRangeExceeds
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
+
+// errorPayload covers both error shapes CoinGecko sends:
+// {"status":{"error_code":429,"error_message":"..."}} and {"error":"coin not found"}
+type errorPayload struct {
+	Status *struct {
+		ErrorCode    int    `json:"error_code"`
+		ErrorMessage string `json:"error_message"`
+	} `json:"status"`
+	Error string `json:"error"`
+}
+
+// newAPIError builds an APIError from a non 200 response and its body
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		Body:       body,
+	}
+	if resp.Request != nil && resp.Request.URL != nil {
+		e.URL = redactURL(resp.Request.URL)
+	}
+	var p errorPayload
+	if err := json.Unmarshal(body, &p); err == nil {
+		switch {
+		case p.Status != nil:
+			e.Code = p.Status.ErrorCode
+			e.Message = p.Status.ErrorMessage
+		case p.Error != "":
+			e.Message = p.Error
+		}
+	}
+	if e.Message == "" {
+		e.Message = strings.TrimSpace(string(body))
+	}
+	return e
+}
+
+// parseRetryAfter parses Retry-After given either in seconds or as an HTTP date
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0
+	}
+	if sec, err := strconv.Atoi(v); err == nil {
+		if sec < 0 {
+			return 0
+		}
+		return time.Duration(sec) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// secretParams query parameters that must never leave the client in logs or errors
+var secretParams = []string{"x_cg_pro_api_key", "x_cg_demo_api_key"}
+
+// redactURL returns u as string with API key query parameters removed
+func redactURL(u *url.URL) string {
+	cp := *u
+	q := cp.Query()
+	changed := false
+	for _, p := range secretParams {
+		if q.Has(p) {
+			q.Del(p)
+			changed = true
+		}
+	}
+	if changed {
+		cp.RawQuery = q.Encode()
+	}
+	cp.User = nil
+	return cp.String()
+}
@@ -0,0 +1,130 @@
+package coingecko
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		header map[string]string
+		body   string
+		is     []error
+		isNot  []error
+		code   int
+		msg    string
+		retry  time.Duration
+	}{
+		{
+			name:   "rate limited",
+			status: http.StatusTooManyRequests,
+			header: map[string]string{"Retry-After": "30"},
+			body:   `{"status":{"error_code":429,"error_message":"You've exceeded the Rate Limit."}}`,
+			is:     []error{ErrRateLimited},
+			isNot:  []error{ErrNotFound, ErrUnauthorized, ErrServer},
+			code:   429,
+			msg:    "You've exceeded the Rate Limit.",
+			retry:  30 * time.Second,
+		},
+		{
+			name:   "not found",
+			status: http.StatusNotFound,
+			body:   `{"error":"coin not found"}`,
+			is:     []error{ErrNotFound},
+			isNot:  []error{ErrRateLimited},
+			msg:    "coin not found",
+		},
+		{
+			name:   "invalid key",
+			status: http.StatusUnauthorized,
+			body:   `{"status":{"error_code":10010,"error_message":"If you are using Pro API key, please change your root URL"}}`,
+			is:     []error{ErrUnauthorized},
+			isNot:  []error{ErrPlanRestricted},
+			code:   10010,
+		},
+		{
+			name:   "plan restricted",
+			status: http.StatusUnauthorized,
+			body:   `{"status":{"error_code":10005,"error_message":"You may not have access to this endpoint."}}`,
+			is:     []error{ErrPlanRestricted},
+			isNot:  []error{ErrUnauthorized},
+			code:   10005,
+		},
+		{
+			name:   "bad gateway html",
+			status: http.StatusBadGateway,
+			body:   "<html>bad gateway</html>",
+			is:     []error{ErrServer},
+			msg:    "<html>bad gateway</html>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tt.header {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+			c := NewClient(Config{BaseUrl: srv.URL})
+
+			_, err := c.Ping(context.Background())
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("want *APIError, got %T %v", err, err)
+			}
+			if apiErr.StatusCode != tt.status || apiErr.Code != tt.code || apiErr.RetryAfter != tt.retry {
+				t.Errorf("got status=%d code=%d retry=%s", apiErr.StatusCode, apiErr.Code, apiErr.RetryAfter)
+			}
+			if tt.msg != "" && apiErr.Message != tt.msg {
+				t.Errorf("message = %q, want %q", apiErr.Message, tt.msg)
+			}
+			if string(apiErr.Body) != tt.body {
+				t.Errorf("body = %q", apiErr.Body)
+			}
+			for _, target := range tt.is {
+				if !errors.Is(err, target) {
+					t.Errorf("errors.Is(%v) = false", target)
+				}
+			}
+			for _, target := range tt.isNot {
+				if errors.Is(err, target) {
+					t.Errorf("errors.Is(%v) = true", target)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2023, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := map[string]time.Duration{
+		"":                              0,
+		"5":                             5 * time.Second,
+		"-1":                            0,
+		"soon":                          0,
+		"Fri, 10 Mar 2023 12:01:00 GMT": time.Minute,
+		"Fri, 10 Mar 2023 11:59:00 GMT": 0,
+	}
+	for in, want := range tests {
+		if got := parseRetryAfter(in, now); got != want {
+			t.Errorf("parseRetryAfter(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	req := httptest.NewRequest("GET", "https://pro-api.coingecko.com/api/v3/ping?x_cg_pro_api_key=secret&a=1", nil)
+	got := redactURL(req.URL)
+	if got != "https://pro-api.coingecko.com/api/v3/ping?a=1" {
+		t.Errorf("redactURL = %s", got)
+	}
+}
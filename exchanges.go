@@ -0,0 +1,149 @@
+package coingecko
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ExchangesIDTickersOrder sort order of ExchangesIDTickers
+type ExchangesIDTickersOrder string
+
+const (
+	ExchangeTickersTrustScoreDesc ExchangesIDTickersOrder = "trust_score_desc"
+	ExchangeTickersTrustScoreAsc  ExchangesIDTickersOrder = "trust_score_asc"
+	ExchangeTickersVolumeDesc     ExchangesIDTickersOrder = "volume_desc"
+	ExchangeTickersVolumeAsc      ExchangesIDTickersOrder = "volume_asc"
+	ExchangeTickersBaseTarget     ExchangesIDTickersOrder = "base_target"
+)
+
+// ExchangesIDTickersRequest /exchanges/{id}/tickers parameters
+type ExchangesIDTickersRequest struct {
+	ID string
+	// CoinIds only tickers of these coins
+	CoinIds []string
+	Page    int
+	// Order the API default when empty
+	Order ExchangesIDTickersOrder
+	// Depth include 2% orderbook depth
+	Depth bool
+}
+
+// Validate checks the request before it is sent
+func (r ExchangesIDTickersRequest) Validate() error {
+	return firstError(
+		required("id", r.ID),
+		optionalList("coin_ids", r.CoinIds),
+		nonNegative("page", r.Page),
+		oneOf("order", string(r.Order), string(ExchangeTickersTrustScoreDesc), string(ExchangeTickersTrustScoreAsc),
+			string(ExchangeTickersVolumeDesc), string(ExchangeTickersVolumeAsc), string(ExchangeTickersBaseTarget)),
+	)
+}
+
+// ExchangesIDTickers /exchanges/{id}/tickers tickers of the exchange, 100 per page
+func (c *Client) ExchangesIDTickers(ctx context.Context, r ExchangesIDTickersRequest) (*ExchangeTickers, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	p := Params{Path: map[string]string{"id": r.ID}}
+	p.Query.List("coin_ids", r.CoinIds).
+		Int("page", r.Page).
+		Opt("order", string(r.Order)).
+		Bool("depth", r.Depth)
+	return Do(ctx, c, Endpoint[*ExchangeTickers]{Name: "ExchangesIDTickers", Route: RouteExchangesIDTickers}, p)
+}
+
+// ExchangesList /exchanges/list id and name of every exchange
+func (c *Client) ExchangesList(ctx context.Context) ([]ExchangeListItem, error) {
+	return Do(ctx, c, Endpoint[[]ExchangeListItem]{Name: "ExchangesList", Route: RouteExchangesList}, Params{})
+}
+
+// VolumePoint trading volume of an exchange in BTC at Time
+type VolumePoint struct {
+	Time      time.Time
+	VolumeBTC float64
+}
+
+// UnmarshalJSON decodes the [timestamp ms, volume] array form, the volume is sent as a decimal string
+func (p *VolumePoint) UnmarshalJSON(b []byte) error {
+	var v [2]json.Number
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	ts, err := v[0].Float64()
+	if err != nil {
+		return err
+	}
+	volume, err := strconv.ParseFloat(v[1].String(), 64)
+	if err != nil {
+		return err
+	}
+	*p = VolumePoint{Time: time.UnixMilli(int64(ts)).UTC(), VolumeBTC: volume}
+	return nil
+}
+
+// VolumeChartDays days values accepted by ExchangesIDVolumeChart
+var VolumeChartDays = []string{"1", "7", "14", "30", "90", "180", "365"}
+
+// maxVolumeChartRange longest range of ExchangesIDVolumeChartRange
+const maxVolumeChartRange = 31 * 24 * time.Hour
+
+// ExchangesIDVolumeChart /exchanges/{id}/volume_chart BTC volume of the exchange over the last days, one of VolumeChartDays
+func (c *Client) ExchangesIDVolumeChart(ctx context.Context, id string, days string) ([]VolumePoint, error) {
+	if err := firstError(required("id", id), required("days", days), oneOf("days", days, VolumeChartDays...)); err != nil {
+		return nil, err
+	}
+	p := Params{Path: map[string]string{"id": id}}
+	p.Query.Set("days", days)
+	return Do(ctx, c, Endpoint[[]VolumePoint]{Name: "ExchangesIDVolumeChart", Route: RouteExchangesIDVolumeChart}, p)
+}
+
+// ExchangesIDVolumeChartRange /exchanges/{id}/volume_chart/range BTC volume of the
+// exchange between from and to, at most 31 days, Pro plan only
+func (c *Client) ExchangesIDVolumeChartRange(ctx context.Context, id string, from time.Time, to time.Time) ([]VolumePoint, error) {
+	if err := firstError(required("id", id), validateRange(from, to)); err != nil {
+		return nil, err
+	}
+	if to.Sub(from) > maxVolumeChartRange {
+		return nil, invalid("to", "ranges are limited to 31 days")
+	}
+	if err := c.requirePro(RouteExchangesIDVolumeChartRange); err != nil {
+		return nil, err
+	}
+	p := Params{Path: map[string]string{"id": id}}
+	p.Query.Set("from", strconv.FormatInt(from.Unix(), 10)).Set("to", strconv.FormatInt(to.Unix(), 10))
+	e := Endpoint[[]VolumePoint]{Name: "ExchangesIDVolumeChartRange", Route: RouteExchangesIDVolumeChartRange}
+	return Do(ctx, c, e, p)
+}
+
+// FromBTC converts a BTC amount to currency vs, e.g. usd, false when there is no rate for vs
+func (r ExchangeRatesItem) FromBTC(amount float64, vs string) (float64, bool) {
+	rate, ok := r[strings.ToLower(vs)]
+	if !ok {
+		return 0, false
+	}
+	return amount * rate.Value, true
+}
+
+// VolumesIn converts the BTC volumes of points to currency vs, e.g. usd, in the
+// same order. The current rate of ExchangeRates applies to every point, so older
+// points are not converted at their historical rate.
+func (c *Client) VolumesIn(ctx context.Context, points []VolumePoint, vs string) ([]float64, error) {
+	if err := required("vs_currency", vs); err != nil {
+		return nil, err
+	}
+	rates, err := c.ExchangeRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := rates.FromBTC(0, vs); !ok {
+		return nil, invalid("vs_currency", strconv.Quote(vs)+" has no exchange rate")
+	}
+	out := make([]float64, len(points))
+	for i, p := range points {
+		out[i], _ = rates.FromBTC(p.VolumeBTC, vs)
+	}
+	return out, nil
+}
@@ -0,0 +1,129 @@
+package coingecko
+
+import (
+	"context"
+	"errors"
+	"golang.org/x/time/rate"
+	"testing"
+	"time"
+)
+
+func TestExchangesID(t *testing.T) {
+	defer srv.Reset()
+	got, err := c.ExchangesID(context.Background(), "binance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Binance" || !got.Centralized || got.TrustScore != 10 || got.TwitterHandle != "binance" ||
+		len(got.Tickers) != 1 || got.Tickers[0].CoinID != "bitcoin" {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestExchangesIDTickers(t *testing.T) {
+	defer srv.Reset()
+	got, err := c.ExchangesIDTickers(context.Background(), ExchangesIDTickersRequest{
+		ID: "binance", CoinIds: []string{"bitcoin", "ethereum"}, Page: 2, Order: ExchangeTickersVolumeDesc, Depth: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Binance" || len(got.Tickers) != 2 || got.Tickers[1].Base != "ETH" {
+		t.Errorf("got %+v", got)
+	}
+	q := srv.LastQuery(RouteExchangesIDTickers)
+	if q.Get("coin_ids") != "bitcoin,ethereum" || q.Get("page") != "2" || q.Get("order") != "volume_desc" || q.Get("depth") != "true" {
+		t.Errorf("query = %v", q)
+	}
+	if _, err := c.ExchangesIDTickers(context.Background(), ExchangesIDTickersRequest{ID: "binance", Order: ExchangeTickersBaseTarget}); err != nil {
+		t.Errorf("base_target order: got %v", err)
+	}
+	if q := srv.LastQuery(RouteExchangesIDTickers); q.Get("order") != "base_target" {
+		t.Errorf("query = %v", q)
+	}
+	if _, err := c.ExchangesIDTickers(context.Background(), ExchangesIDTickersRequest{ID: "binance", Order: "name"}); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("unknown order: got %v", err)
+	}
+}
+
+func TestExchangesList(t *testing.T) {
+	defer srv.Reset()
+	got, err := c.ExchangesList(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[1] != (ExchangeListItem{ID: "gdax", Name: "Coinbase Exchange"}) {
+		t.Errorf("got %+v", got)
+	}
+}
+
+func TestExchangeRates(t *testing.T) {
+	defer srv.Reset()
+	got, err := c.ExchangeRates(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (*got)["usd"].Value != 20154 || (*got)["btc"].Type != "crypto" {
+		t.Errorf("got %+v", got)
+	}
+	if v, ok := got.FromBTC(2, "USD"); !ok || v != 40308 {
+		t.Errorf("FromBTC = %v, %v", v, ok)
+	}
+	if _, ok := got.FromBTC(2, "xyz"); ok {
+		t.Error("FromBTC of unknown currency")
+	}
+}
+
+func TestExchangesIDVolumeChart(t *testing.T) {
+	defer srv.Reset()
+	ctx := context.Background()
+	got, err := c.ExchangesIDVolumeChart(ctx, "binance", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := VolumePoint{Time: time.Date(2024, 3, 30, 9, 50, 0, 0, time.UTC), VolumeBTC: 306800.0517941023777005}
+	if len(got) != 3 || got[0] != want {
+		t.Errorf("got %+v", got)
+	}
+	if q := srv.LastQuery(RouteExchangesIDVolumeChart); q.Get("days") != "1" {
+		t.Errorf("query = %v", q)
+	}
+	if _, err := c.ExchangesIDVolumeChart(ctx, "binance", "max"); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("days max: got %v", err)
+	}
+
+	usd, err := c.VolumesIn(ctx, got, "usd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usd) != 3 || usd[0] != got[0].VolumeBTC*20154 {
+		t.Errorf("usd volumes %v", usd)
+	}
+	if _, err := c.VolumesIn(ctx, got, "xyz"); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("unknown currency: got %v", err)
+	}
+}
+
+func TestExchangesIDVolumeChartRange(t *testing.T) {
+	defer srv.Reset()
+	ctx := context.Background()
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 7)
+	if _, err := c.ExchangesIDVolumeChartRange(ctx, "binance", from, to); !errors.Is(err, ErrPlanRestricted) {
+		t.Errorf("public plan: got %v", err)
+	}
+	pro := NewClient(Config{BaseUrl: srv.URL, APIKey: "key", Plan: PlanPro, RateLimiter: rate.NewLimiter(rate.Inf, 1)})
+	got, err := pro.ExchangesIDVolumeChartRange(ctx, "binance", from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Errorf("got %+v", got)
+	}
+	if q := srv.LastQuery(RouteExchangesIDVolumeChartRange); q.Get("from") != "1709251200" || q.Get("to") != "1709856000" {
+		t.Errorf("query = %v", q)
+	}
+	if _, err := pro.ExchangesIDVolumeChartRange(ctx, "binance", from, from.AddDate(0, 0, 32)); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("long range: got %v", err)
+	}
+}
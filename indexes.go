@@ -0,0 +1,37 @@
+package coingecko
+
+import (
+	"context"
+)
+
+// Indexes /indexes market indexes, the API defaults apply to zero perPage or page
+func (c *Client) Indexes(ctx context.Context, perPage int, page int) ([]Index, error) {
+	if err := firstError(nonNegative("per_page", perPage), nonNegative("page", page)); err != nil {
+		return nil, err
+	}
+	var p Params
+	p.Query.Int("per_page", perPage).Int("page", page)
+	return Do(ctx, c, Endpoint[[]Index]{Name: "Indexes", Route: RouteIndexes}, p)
+}
+
+// IndexByMarket /indexes/{market_id}/{id} index id of the market, e.g. BTC of binance_futures
+func (c *Client) IndexByMarket(ctx context.Context, marketID string, id string) (*Index, error) {
+	if err := firstError(required("market_id", marketID), required("id", id)); err != nil {
+		return nil, err
+	}
+	p := Params{Path: map[string]string{"market_id": marketID, "id": id}}
+	data, err := Do(ctx, c, Endpoint[*Index]{Name: "IndexByMarket", Route: RouteIndexesMarketID}, p)
+	if err != nil {
+		return nil, err
+	}
+	// the response only names the index, keep the id it was requested by
+	if data != nil && data.ID == "" {
+		data.ID = id
+	}
+	return data, nil
+}
+
+// IndexesList /indexes/list id and name of every index
+func (c *Client) IndexesList(ctx context.Context) ([]IndexListItem, error) {
+	return Do(ctx, c, Endpoint[[]IndexListItem]{Name: "IndexesList", Route: RouteIndexesList}, Params{})
+}
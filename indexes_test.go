@@ -0,0 +1,49 @@
+package coingecko
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestIndexes(t *testing.T) {
+	defer srv.Reset()
+	ctx := context.Background()
+	got, err := c.Indexes(ctx, 3, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || *got[0].Last != 69393.8 || *got[1].Last != 3412.55 || got[2].Last != nil || got[2].IsMultiAssetComposite != nil {
+		t.Errorf("got %+v", got)
+	}
+	if q := srv.LastQuery(RouteIndexes); q.Get("per_page") != "3" || q.Get("page") != "2" {
+		t.Errorf("query = %v", q)
+	}
+	if _, err := c.Indexes(ctx, 0, -1); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("negative page: got %v", err)
+	}
+
+	index, err := c.IndexByMarket(ctx, "binance_futures", "BTC")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if index.ID != "BTC" || index.Market != "Binance (Futures)" || *index.Last != 69393.8 || *index.IsMultiAssetComposite {
+		t.Errorf("index %+v", index)
+	}
+	reqs := srv.Requests(RouteIndexesMarketID)
+	if len(reqs) != 1 || !strings.HasSuffix(reqs[0].Path, "/indexes/binance_futures/BTC") {
+		t.Errorf("requests %+v", reqs)
+	}
+	if _, err := c.IndexByMarket(ctx, "", "BTC"); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("missing market: got %v", err)
+	}
+
+	list, err := c.IndexesList(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 3 || list[2].ID != "BVOL24H" {
+		t.Errorf("list %+v", list)
+	}
+}
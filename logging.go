@@ -0,0 +1,111 @@
+package coingecko
+
+import (
+	"context"
+	"log/slog"
+	"net/url"
+	"os"
+	"time"
+)
+
+// Logger receives structured events for every request, *slog.Logger satisfies it
+type Logger interface {
+	Enabled(ctx context.Context, level slog.Level) bool
+	LogAttrs(ctx context.Context, level slog.Level, msg string, attrs ...slog.Attr)
+}
+
+// LevelBody level of full response body dumps, below slog.LevelDebug so
+// bodies are only logged when the handler is explicitly configured for them
+const LevelBody = slog.LevelDebug - 4
+
+// defaultBodyLogLimit bytes of a response body logged at LevelBody
+const defaultBodyLogLimit = 4096
+
+// debugLogger logger used when Config.Debug is set without a Logger
+func debugLogger() Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: LevelBody}))
+}
+
+// requestEvent single served call or upstream attempt
+type requestEvent struct {
+	route    string
+	url      *url.URL
+	attempt  int
+	status   int
+	latency  time.Duration
+	wait     time.Duration
+	bytes    int
+	cacheHit bool
+	err      error
+}
+
+// logRequest emits ev at debug level, or warn level when it failed
+func (c *Client) logRequest(ctx context.Context, ev requestEvent) {
+	if c.logger == nil {
+		return
+	}
+	level := slog.LevelDebug
+	if ev.err != nil {
+		level = slog.LevelWarn
+	}
+	if !c.logger.Enabled(ctx, level) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String("endpoint", ev.route),
+		slog.String("params", redactQuery(ev.url)),
+		slog.Bool("cache_hit", ev.cacheHit),
+	}
+	if !ev.cacheHit {
+		attrs = append(attrs,
+			slog.Int("attempt", ev.attempt),
+			slog.Int("status", ev.status),
+			slog.Duration("latency", ev.latency),
+			slog.Duration("limiter_wait", ev.wait),
+		)
+	}
+	attrs = append(attrs, slog.Int("bytes", ev.bytes))
+	if ev.err != nil {
+		attrs = append(attrs, slog.String("error", c.redactKey(ev.err.Error())))
+	}
+	c.logger.LogAttrs(ctx, level, "coingecko request", attrs...)
+}
+
+// logsBodies reports whether response bodies are logged, only then successful
+// bodies are buffered instead of decoded as they stream in
+func (c *Client) logsBodies(ctx context.Context) bool {
+	return c.logger != nil && c.logger.Enabled(ctx, LevelBody)
+}
+
+// logBody dumps a response body at LevelBody, redacted and truncated to Config.BodyLogLimit
+func (c *Client) logBody(ctx context.Context, route string, u *url.URL, body []byte) {
+	if !c.logsBodies(ctx) {
+		return
+	}
+	limit := c.cfg.BodyLogLimit
+	if limit == 0 {
+		limit = defaultBodyLogLimit
+	}
+	truncated := false
+	if limit > 0 && len(body) > limit {
+		body, truncated = body[:limit], true
+	}
+	c.logger.LogAttrs(ctx, LevelBody, "coingecko response body",
+		slog.String("endpoint", route),
+		slog.String("url", redactURL(u)),
+		slog.String("body", c.redactKey(string(body))),
+		slog.Bool("truncated", truncated),
+	)
+}
+
+// redactQuery query string of u without API key parameters
+func redactQuery(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	q := u.Query()
+	for _, p := range secretParams {
+		q.Del(p)
+	}
+	return q.Encode()
+}
@@ -0,0 +1,100 @@
+package coingecko
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"golang.org/x/time/rate"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+type logRecord map[string]interface{}
+
+func newTestLogger(level slog.Level) (*slog.Logger, func() []logRecord) {
+	r, w := io.Pipe()
+	var buf strings.Builder
+	done := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(&buf, r)
+		close(done)
+	}()
+	logger := slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))
+	return logger, func() []logRecord {
+		_ = w.Close()
+		<-done
+		var out []logRecord
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			var rec logRecord
+			_ = json.Unmarshal([]byte(line), &rec)
+			out = append(out, rec)
+		}
+		return out
+	}
+}
+
+func TestLogger(t *testing.T) {
+	defer srv.Reset()
+	logger, records := newTestLogger(slog.LevelDebug)
+	c := NewClient(Config{
+		BaseUrl:     srv.URL,
+		RateLimiter: rate.NewLimiter(rate.Inf, 1),
+		Logger:      logger,
+		Cache:       NewLRUCache(10),
+	})
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if _, err := c.SimplePrice(ctx, []string{"bitcoin"}, []string{"usd"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	srv.RateLimit("/coins/list", 1, time.Second)
+	if _, err := c.CoinsList(ctx); !errors.Is(err, ErrRateLimited) {
+		t.Fatal(err)
+	}
+
+	got := records()
+	if len(got) != 3 {
+		t.Fatalf("got %d records: %v", len(got), got)
+	}
+	miss, hit, failed := got[0], got[1], got[2]
+	if miss["level"] != "DEBUG" || miss["endpoint"] != RouteSimplePrice || miss["params"] != "ids=bitcoin&vs_currencies=usd" ||
+		miss["status"] != float64(200) || miss["cache_hit"] != false || miss["bytes"].(float64) == 0 {
+		t.Errorf("miss record %v", miss)
+	}
+	if _, ok := miss["latency"]; !ok {
+		t.Errorf("miss record without latency %v", miss)
+	}
+	if _, ok := miss["limiter_wait"]; !ok {
+		t.Errorf("miss record without limiter_wait %v", miss)
+	}
+	if hit["cache_hit"] != true {
+		t.Errorf("hit record %v", hit)
+	}
+	if failed["level"] != "WARN" || failed["status"] != float64(429) || failed["error"] == nil {
+		t.Errorf("failed record %v", failed)
+	}
+}
+
+func TestLoggerBody(t *testing.T) {
+	logger, records := newTestLogger(LevelBody)
+	c := NewClient(Config{BaseUrl: srv.URL, RateLimiter: rate.NewLimiter(rate.Inf, 1), Logger: logger, BodyLogLimit: 10})
+	if _, err := c.Ping(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	var body logRecord
+	for _, rec := range records() {
+		if rec["msg"] == "coingecko response body" {
+			body = rec
+		}
+	}
+	if body == nil || body["body"] != `{"gecko_sa` || body["truncated"] != true {
+		t.Errorf("body record %v", body)
+	}
+}
@@ -0,0 +1,67 @@
+package coingecko
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// Granularity interval between the points of a market chart
+type Granularity string
+
+// Granularities picked by CoinGecko for a chart, the values match the interval parameter
+const (
+	Granularity5Minute Granularity = "5m"
+	GranularityHourly  Granularity = "hourly"
+	GranularityDaily   Granularity = "daily"
+)
+
+// MarketChartRange market chart of a time range and the granularity of its points
+type MarketChartRange struct {
+	CoinsIDMarketChart
+	Granularity Granularity
+}
+
+// rangeGranularity granularity CoinGecko applies to the range from-to: 5-minute
+// data for at most a day ending at the current time, hourly data up to 90 days
+// and daily data above
+func rangeGranularity(from time.Time, to time.Time, now time.Time) Granularity {
+	span := to.Sub(from)
+	switch {
+	case span > 90*24*time.Hour:
+		return GranularityDaily
+	case span <= 24*time.Hour && now.Sub(from) <= 24*time.Hour:
+		return Granularity5Minute
+	}
+	return GranularityHourly
+}
+
+// validateRange from and to set and from before to
+func validateRange(from time.Time, to time.Time) error {
+	switch {
+	case from.IsZero():
+		return invalid("from", "is required")
+	case to.IsZero():
+		return invalid("to", "is required")
+	case !from.Before(to):
+		return invalid("from", "must be before to")
+	}
+	return nil
+}
+
+// CoinsIDMarketChartRange /coins/{id}/market_chart/range prices, market caps and volumes between from and to
+func (c *Client) CoinsIDMarketChartRange(ctx context.Context, id string, vsCurrency string, from time.Time, to time.Time) (*MarketChartRange, error) {
+	if err := firstError(required("id", id), required("vs_currency", vsCurrency), validateRange(from, to)); err != nil {
+		return nil, err
+	}
+	p := Params{Path: map[string]string{"id": id}}
+	p.Query.Set("vs_currency", vsCurrency).
+		Set("from", strconv.FormatInt(from.Unix(), 10)).
+		Set("to", strconv.FormatInt(to.Unix(), 10))
+	e := Endpoint[CoinsIDMarketChart]{Name: "CoinsIDMarketChartRange", Route: RouteCoinsIDMarketChartRange}
+	data, err := Do(ctx, c, e, p, coinAttrs(id, vsCurrency)...)
+	if err != nil {
+		return nil, err
+	}
+	return &MarketChartRange{CoinsIDMarketChart: data, Granularity: rangeGranularity(from, to, time.Now())}, nil
+}
@@ -0,0 +1,63 @@
+package coingecko
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCoinsIDMarketChartRange(t *testing.T) {
+	defer srv.Reset()
+	from := time.Date(2023, 3, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 3, 14, 0, 0, 0, 0, time.UTC)
+	got, err := c.CoinsIDMarketChartRange(context.Background(), "bitcoin", "usd", from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Prices) != 5 || got.Prices[4][1] != 24201.8 || got.Granularity != GranularityHourly {
+		t.Errorf("got %+v", got)
+	}
+	q := srv.LastQuery(RouteCoinsIDMarketChartRange)
+	if q.Get("from") != "1678406400" || q.Get("to") != "1678752000" || q.Get("vs_currency") != "usd" {
+		t.Errorf("query = %v", q)
+	}
+
+	for _, tt := range []struct {
+		from, to time.Time
+		field    string
+	}{
+		{time.Time{}, to, "from"},
+		{from, time.Time{}, "to"},
+		{to, from, "from"},
+		{from, from, "from"},
+	} {
+		_, err := c.CoinsIDMarketChartRange(context.Background(), "bitcoin", "usd", tt.from, tt.to)
+		var invalid *InvalidArgumentError
+		if !errors.As(err, &invalid) || invalid.Field != tt.field {
+			t.Errorf("%v - %v: got %v, want invalid %s", tt.from, tt.to, err, tt.field)
+		}
+	}
+}
+
+func TestRangeGranularity(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	tests := []struct {
+		from, to time.Time
+		want     Granularity
+	}{
+		{now.Add(-6 * time.Hour), now, Granularity5Minute},
+		{now.Add(-day), now, Granularity5Minute},
+		{now.Add(-10 * day), now.Add(-9 * day), GranularityHourly},
+		{now.Add(-2 * day), now, GranularityHourly},
+		{now.Add(-90 * day), now, GranularityHourly},
+		{now.Add(-91 * day), now, GranularityDaily},
+		{now.Add(-400 * day), now.Add(-300 * day), GranularityDaily},
+	}
+	for _, tt := range tests {
+		if got := rangeGranularity(tt.from, tt.to, now); got != tt.want {
+			t.Errorf("%v - %v: got %s, want %s", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
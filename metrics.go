@@ -0,0 +1,65 @@
+package coingecko
+
+import (
+	"time"
+)
+
+// Metrics receives per-endpoint instrumentation from the client. endpoint is
+// the route template, e.g. /coins/{id}/market_chart, or RouteOther for URLs
+// matching no known route, so label cardinality stays bounded.
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveRequest one upstream attempt, status is 0 when no response was received
+	ObserveRequest(endpoint string, status int, latency time.Duration)
+	// ObserveLimiterWait time an attempt waited on the rate limiter
+	ObserveLimiterWait(endpoint string, wait time.Duration)
+	// IncRetry an attempt is about to be retried
+	IncRetry(endpoint string)
+	// IncCacheHit a call was served from Config.Cache
+	IncCacheHit(endpoint string)
+	// IncCacheMiss a cacheable call went upstream
+	IncCacheMiss(endpoint string)
+}
+
+// RouteOther endpoint reported to Metrics for requests matching no known route,
+// e.g. custom MakeReq URLs
+const RouteOther = "other"
+
+var knownRoutes = func() map[string]bool {
+	m := make(map[string]bool, len(routes))
+	for _, r := range routes {
+		m[r] = true
+	}
+	return m
+}()
+
+// routeMetrics reports requests outside the known routes as RouteOther
+type routeMetrics struct {
+	m Metrics
+}
+
+func metricsEndpoint(route string) string {
+	if knownRoutes[route] {
+		return route
+	}
+	return RouteOther
+}
+
+func (r routeMetrics) ObserveRequest(endpoint string, status int, latency time.Duration) {
+	r.m.ObserveRequest(metricsEndpoint(endpoint), status, latency)
+}
+func (r routeMetrics) ObserveLimiterWait(endpoint string, wait time.Duration) {
+	r.m.ObserveLimiterWait(metricsEndpoint(endpoint), wait)
+}
+func (r routeMetrics) IncRetry(endpoint string)     { r.m.IncRetry(metricsEndpoint(endpoint)) }
+func (r routeMetrics) IncCacheHit(endpoint string)  { r.m.IncCacheHit(metricsEndpoint(endpoint)) }
+func (r routeMetrics) IncCacheMiss(endpoint string) { r.m.IncCacheMiss(metricsEndpoint(endpoint)) }
+
+// nopMetrics used when Config.Metrics is nil
+type nopMetrics struct{}
+
+func (nopMetrics) ObserveRequest(string, int, time.Duration) {}
+func (nopMetrics) ObserveLimiterWait(string, time.Duration)  {}
+func (nopMetrics) IncRetry(string)                           {}
+func (nopMetrics) IncCacheHit(string)                        {}
+func (nopMetrics) IncCacheMiss(string)                       {}
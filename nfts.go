@@ -0,0 +1,60 @@
+package coingecko
+
+import (
+	"context"
+)
+
+// NFTsOrder sort order of NFTsList
+type NFTsOrder string
+
+const (
+	NFTsH24VolumeNativeAsc   NFTsOrder = "h24_volume_native_asc"
+	NFTsH24VolumeNativeDesc  NFTsOrder = "h24_volume_native_desc"
+	NFTsH24VolumeUsdAsc      NFTsOrder = "h24_volume_usd_asc"
+	NFTsH24VolumeUsdDesc     NFTsOrder = "h24_volume_usd_desc"
+	NFTsFloorPriceNativeAsc  NFTsOrder = "floor_price_native_asc"
+	NFTsFloorPriceNativeDesc NFTsOrder = "floor_price_native_desc"
+	NFTsMarketCapNativeAsc   NFTsOrder = "market_cap_native_asc"
+	NFTsMarketCapNativeDesc  NFTsOrder = "market_cap_native_desc"
+	NFTsMarketCapUsdAsc      NFTsOrder = "market_cap_usd_asc"
+	NFTsMarketCapUsdDesc     NFTsOrder = "market_cap_usd_desc"
+)
+
+// NFTsList /nfts/list, the API defaults apply to an empty order and zero perPage or page
+func (c *Client) NFTsList(ctx context.Context, order NFTsOrder, perPage int, page int) ([]NFTListItem, error) {
+	err := firstError(
+		oneOf("order", string(order), string(NFTsH24VolumeNativeAsc), string(NFTsH24VolumeNativeDesc),
+			string(NFTsH24VolumeUsdAsc), string(NFTsH24VolumeUsdDesc),
+			string(NFTsFloorPriceNativeAsc), string(NFTsFloorPriceNativeDesc),
+			string(NFTsMarketCapNativeAsc), string(NFTsMarketCapNativeDesc),
+			string(NFTsMarketCapUsdAsc), string(NFTsMarketCapUsdDesc)),
+		nonNegative("per_page", perPage),
+		nonNegative("page", page),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var p Params
+	p.Query.Opt("order", string(order)).Int("per_page", perPage).Int("page", page)
+	return Do(ctx, c, Endpoint[[]NFTListItem]{Name: "NFTsList", Route: RouteNFTsList}, p)
+}
+
+// NFTsID /nfts/{id} collection data, id as returned by NFTsList
+func (c *Client) NFTsID(ctx context.Context, id string) (*NFTCollection, error) {
+	if err := required("id", id); err != nil {
+		return nil, err
+	}
+	p := Params{Path: map[string]string{"id": id}}
+	return Do(ctx, c, Endpoint[*NFTCollection]{Name: "NFTsID", Route: RouteNFTsID}, p)
+}
+
+// NFTsContractAddress /nfts/{asset_platform_id}/contract/{contract_address} collection data
+// of the contract on the asset platform, e.g. ethereum
+func (c *Client) NFTsContractAddress(ctx context.Context, platform string, address string) (*NFTCollection, error) {
+	if err := firstError(required("asset_platform_id", platform), required("contract_address", address)); err != nil {
+		return nil, err
+	}
+	p := Params{Path: map[string]string{"asset_platform_id": platform, "contract_address": normalizeAddress(platform, address)}}
+	e := Endpoint[*NFTCollection]{Name: "NFTsContractAddress", Route: RouteNFTsContractAddress}
+	return Do(ctx, c, e, p)
+}
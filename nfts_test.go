@@ -0,0 +1,60 @@
+package coingecko
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNFTsList(t *testing.T) {
+	defer srv.Reset()
+	ctx := context.Background()
+	got, err := c.NFTsList(ctx, NFTsMarketCapUsdDesc, 3, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 || got[0].ID != "pudgy-penguins" || got[2].AssetPlatformID != "solana" {
+		t.Errorf("got %+v", got)
+	}
+	q := srv.LastQuery(RouteNFTsList)
+	if q.Get("order") != "market_cap_usd_desc" || q.Get("per_page") != "3" || q.Get("page") != "1" {
+		t.Errorf("query = %v", q)
+	}
+	if _, err := c.NFTsList(ctx, "floor_price_usd_desc", 0, 0); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("unknown order: got %v", err)
+	}
+}
+
+func TestNFTsID(t *testing.T) {
+	defer srv.Reset()
+	ctx := context.Background()
+	got, err := c.NFTsID(ctx, "pudgy-penguins")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.FloorPrice.NativeCurrency != 12.17 || got.FloorPrice.USD != 44360 || got.MarketCap.USD != 394267328 ||
+		got.Volume24h.NativeCurrency != 402.37 || got.FloorPrice24hPercentageChange.NativeCurrency != 1.2345 ||
+		got.NumberOfUniqueAddresses != 4752 || got.Links.Twitter != "https://twitter.com/pudgypenguins" {
+		t.Errorf("got %+v", got)
+	}
+	if _, err := c.NFTsID(ctx, ""); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("missing id: got %v", err)
+	}
+
+	const address = "0xBd3531dA5CF5857e7CfAA92426877b022e612cf8"
+	byContract, err := c.NFTsContractAddress(ctx, "ethereum", address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if byContract.ID != "pudgy-penguins" {
+		t.Errorf("got %+v", byContract)
+	}
+	reqs := srv.Requests(RouteNFTsContractAddress)
+	if len(reqs) != 1 || !strings.HasSuffix(reqs[0].Path, "/nfts/ethereum/contract/"+strings.ToLower(address)) {
+		t.Errorf("requests %+v", reqs)
+	}
+	if _, err := c.NFTsContractAddress(ctx, "", address); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("missing platform: got %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package coingecko
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// FlexFloat float64 decoded from a JSON number or a numeric string, CoinGecko
+// sends some values either way. null and empty strings decode to 0
+type FlexFloat float64
+
+// UnmarshalJSON accepts 1.5, "1.5", "" and null
+func (f *FlexFloat) UnmarshalJSON(b []byte) error {
+	b = bytes.Trim(b, `"`)
+	if len(b) == 0 || string(b) == "null" {
+		*f = 0
+		return nil
+	}
+	v, err := strconv.ParseFloat(string(b), 64)
+	if err != nil {
+		return fmt.Errorf("coingecko: cannot decode %s as a number", b)
+	}
+	*f = FlexFloat(v)
+	return nil
+}
+
+// UnixTime time decoded from unix seconds, null decodes to the zero time
+type UnixTime struct {
+	time.Time
+}
+
+// UnmarshalJSON accepts integer or fractional unix seconds and null
+func (t *UnixTime) UnmarshalJSON(b []byte) error {
+	var f FlexFloat
+	if err := f.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	if f == 0 {
+		t.Time = time.Time{}
+		return nil
+	}
+	t.Time = time.Unix(0, int64(float64(f)*float64(time.Second))).UTC()
+	return nil
+}
@@ -0,0 +1,86 @@
+package coingecko
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Candle OHLC price candle, Time is the close time of the candle
+type Candle struct {
+	Time  time.Time
+	Open  float64
+	High  float64
+	Low   float64
+	Close float64
+}
+
+// UnmarshalJSON decodes the [timestamp ms, open, high, low, close] array form
+func (c *Candle) UnmarshalJSON(b []byte) error {
+	var v [5]float64
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*c = Candle{Time: time.UnixMilli(int64(v[0])).UTC(), Open: v[1], High: v[2], Low: v[3], Close: v[4]}
+	return nil
+}
+
+// OHLCDays days values accepted by CoinsIDOHLC
+var OHLCDays = []string{"1", "7", "14", "30", "90", "180", "365", "max"}
+
+// OHLC range intervals and the longest range CoinGecko serves for each
+const (
+	OHLCIntervalDaily  = "daily"
+	OHLCIntervalHourly = "hourly"
+
+	maxOHLCDailyRange  = 180 * 24 * time.Hour
+	maxOHLCHourlyRange = 31 * 24 * time.Hour
+)
+
+// CoinsIDOHLC /coins/{id}/ohlc candles of the last days, one of OHLCDays
+func (c *Client) CoinsIDOHLC(ctx context.Context, id string, vsCurrency string, days string) ([]Candle, error) {
+	err := firstError(
+		required("id", id),
+		required("vs_currency", vsCurrency),
+		required("days", days),
+		oneOf("days", days, OHLCDays...),
+	)
+	if err != nil {
+		return nil, err
+	}
+	p := Params{Path: map[string]string{"id": id}}
+	p.Query.Set("vs_currency", vsCurrency).Set("days", days)
+	return Do(ctx, c, Endpoint[[]Candle]{Name: "CoinsIDOHLC", Route: RouteCoinsIDOHLC}, p, coinAttrs(id, vsCurrency)...)
+}
+
+// CoinsIDOHLCRange /coins/{id}/ohlc/range candles between from and to, Pro plan only.
+// interval is OHLCIntervalDaily for up to 180 days or OHLCIntervalHourly for up to 31 days
+func (c *Client) CoinsIDOHLCRange(ctx context.Context, id string, vsCurrency string, from time.Time, to time.Time, interval string) ([]Candle, error) {
+	err := firstError(
+		required("id", id),
+		required("vs_currency", vsCurrency),
+		validateRange(from, to),
+		required("interval", interval),
+		oneOf("interval", interval, OHLCIntervalDaily, OHLCIntervalHourly),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if interval == OHLCIntervalDaily && to.Sub(from) > maxOHLCDailyRange {
+		return nil, invalid("to", "daily ranges are limited to 180 days")
+	}
+	if interval == OHLCIntervalHourly && to.Sub(from) > maxOHLCHourlyRange {
+		return nil, invalid("to", "hourly ranges are limited to 31 days")
+	}
+	if err := c.requirePro(RouteCoinsIDOHLCRange); err != nil {
+		return nil, err
+	}
+	p := Params{Path: map[string]string{"id": id}}
+	p.Query.Set("vs_currency", vsCurrency).
+		Set("from", strconv.FormatInt(from.Unix(), 10)).
+		Set("to", strconv.FormatInt(to.Unix(), 10)).
+		Set("interval", interval)
+	e := Endpoint[[]Candle]{Name: "CoinsIDOHLCRange", Route: RouteCoinsIDOHLCRange}
+	return Do(ctx, c, e, p, coinAttrs(id, vsCurrency)...)
+}
@@ -0,0 +1,75 @@
+package coingecko
+
+import (
+	"context"
+	"errors"
+	"golang.org/x/time/rate"
+	"testing"
+	"time"
+)
+
+func TestCoinsIDOHLC(t *testing.T) {
+	defer srv.Reset()
+	got, err := c.CoinsIDOHLC(context.Background(), "bitcoin", "usd", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Candle{Time: time.Date(2024, 3, 2, 16, 0, 0, 0, time.UTC), Open: 61942, High: 62211, Low: 61721, Close: 61845}
+	if len(got) != 3 || got[0] != want {
+		t.Errorf("got %+v", got)
+	}
+	if q := srv.LastQuery(RouteCoinsIDOHLC); q.Get("days") != "1" || q.Get("vs_currency") != "usd" {
+		t.Errorf("query = %v", q)
+	}
+	for _, days := range []string{"", "2", "60", "all"} {
+		if _, err := c.CoinsIDOHLC(context.Background(), "bitcoin", "usd", days); !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("days %q: got %v", days, err)
+		}
+	}
+}
+
+func TestCoinsIDOHLCRange(t *testing.T) {
+	defer srv.Reset()
+	ctx := context.Background()
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	if _, err := c.CoinsIDOHLCRange(ctx, "bitcoin", "usd", from, to, OHLCIntervalDaily); !errors.Is(err, ErrPlanRestricted) {
+		t.Errorf("public plan: got %v", err)
+	}
+	if len(srv.Requests(RouteCoinsIDOHLCRange)) != 0 {
+		t.Error("public plan request sent")
+	}
+
+	pro := NewClient(Config{BaseUrl: srv.URL, APIKey: "key", Plan: PlanPro, RateLimiter: rate.NewLimiter(rate.Inf, 1)})
+	got, err := pro.CoinsIDOHLCRange(ctx, "bitcoin", "usd", from, to, OHLCIntervalDaily)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[1].Close != 62050 {
+		t.Errorf("got %+v", got)
+	}
+	q := srv.LastQuery(RouteCoinsIDOHLCRange)
+	if q.Get("from") != "1709251200" || q.Get("to") != "1709424000" || q.Get("interval") != "daily" {
+		t.Errorf("query = %v", q)
+	}
+
+	tests := []struct {
+		from, to time.Time
+		interval string
+		field    string
+	}{
+		{from, to, "", "interval"},
+		{from, to, "5m", "interval"},
+		{to, from, OHLCIntervalDaily, "from"},
+		{from, from.AddDate(0, 0, 32), OHLCIntervalHourly, "to"},
+		{from, from.AddDate(0, 0, 181), OHLCIntervalDaily, "to"},
+	}
+	for _, tt := range tests {
+		_, err := pro.CoinsIDOHLCRange(ctx, "bitcoin", "usd", tt.from, tt.to, tt.interval)
+		var invalid *InvalidArgumentError
+		if !errors.As(err, &invalid) || invalid.Field != tt.field {
+			t.Errorf("%v - %v %s: got %v, want invalid %s", tt.from, tt.to, tt.interval, err, tt.field)
+		}
+	}
+}
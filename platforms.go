@@ -0,0 +1,60 @@
+package coingecko
+
+import (
+	"context"
+	"fmt"
+)
+
+// AssetPlatform blockchain network tokens live on, its ID is the platform of the contract and token price calls
+type AssetPlatform struct {
+	ID string `json:"id"`
+	// ChainIdentifier EVM chain id, nil for non EVM chains such as Solana
+	ChainIdentifier *int64    `json:"chain_identifier"`
+	Name            string    `json:"name"`
+	Shortname       string    `json:"shortname"`
+	NativeCoinID    string    `json:"native_coin_id"`
+	Image           ImageItem `json:"image"`
+}
+
+// AssetPlatforms list of asset platforms
+type AssetPlatforms []AssetPlatform
+
+// ByChainID platform of the EVM chain id, e.g. 137 for polygon-pos
+func (l AssetPlatforms) ByChainID(chainID int64) (AssetPlatform, bool) {
+	for _, p := range l {
+		if p.ChainIdentifier != nil && *p.ChainIdentifier == chainID {
+			return p, true
+		}
+	}
+	return AssetPlatform{}, false
+}
+
+// AssetPlatformFilter filter of AssetPlatforms
+type AssetPlatformFilter string
+
+// AssetPlatformFilterNFT only platforms supporting NFTs
+const AssetPlatformFilterNFT AssetPlatformFilter = "nft"
+
+// AssetPlatforms /asset_platforms, all platforms when filter is empty
+func (c *Client) AssetPlatforms(ctx context.Context, filter AssetPlatformFilter) (AssetPlatforms, error) {
+	if err := oneOf("filter", string(filter), string(AssetPlatformFilterNFT)); err != nil {
+		return nil, err
+	}
+	var p Params
+	p.Query.Opt("filter", string(filter))
+	return Do(ctx, c, Endpoint[AssetPlatforms]{Name: "AssetPlatforms", Route: RouteAssetPlatforms}, p)
+}
+
+// PlatformIDByChainID platform id of the EVM chain id, e.g. polygon-pos for 137.
+// The platform list is fetched on every call, set Config.Cache to keep it for DefaultCacheTTL.
+func (c *Client) PlatformIDByChainID(ctx context.Context, chainID int64) (string, error) {
+	platforms, err := c.AssetPlatforms(ctx, "")
+	if err != nil {
+		return "", err
+	}
+	p, ok := platforms.ByChainID(chainID)
+	if !ok {
+		return "", fmt.Errorf("%w: no asset platform with chain id %d", ErrNotFound, chainID)
+	}
+	return p.ID, nil
+}
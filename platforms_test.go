@@ -0,0 +1,50 @@
+package coingecko
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestAssetPlatforms(t *testing.T) {
+	defer srv.Reset()
+	ctx := context.Background()
+	got, err := c.AssetPlatforms(ctx, AssetPlatformFilterNFT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 4 || got[1].ID != "polygon-pos" || *got[1].ChainIdentifier != 137 || got[1].NativeCoinID != "matic-network" ||
+		got[1].Shortname != "MATIC" || got[3].ChainIdentifier != nil {
+		t.Errorf("got %+v", got)
+	}
+	if q := srv.LastQuery(RouteAssetPlatforms); q.Get("filter") != "nft" {
+		t.Errorf("query = %v", q)
+	}
+	if _, err := c.AssetPlatforms(ctx, "evm"); !errors.Is(err, ErrInvalidArgument) {
+		t.Errorf("unknown filter: got %v", err)
+	}
+}
+
+func TestPlatformIDByChainID(t *testing.T) {
+	defer srv.Reset()
+	ctx := context.Background()
+	tests := []struct {
+		chainID int64
+		want    string
+	}{
+		{1, "ethereum"},
+		{137, "polygon-pos"},
+		{56, "binance-smart-chain"},
+	}
+	for _, tt := range tests {
+		if got, err := c.PlatformIDByChainID(ctx, tt.chainID); err != nil || got != tt.want {
+			t.Errorf("%d: got %s, %v, want %s", tt.chainID, got, err, tt.want)
+		}
+	}
+	if q := srv.LastQuery(RouteAssetPlatforms); q.Has("filter") {
+		t.Errorf("query = %v", q)
+	}
+	if _, err := c.PlatformIDByChainID(ctx, 999999); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown chain: got %v", err)
+	}
+}
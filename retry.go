@@ -0,0 +1,144 @@
+package coingecko
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy controls how MakeReq retries failed requests.
+// A nil policy in Config disables retries.
+type RetryPolicy struct {
+	// MaxAttempts total number of attempts including the first one, values below 2 disable retries
+	MaxAttempts int
+	// BaseBackoff wait before the second attempt, doubled on every further attempt
+	BaseBackoff time.Duration
+	// MaxBackoff upper bound for the computed backoff, Retry-After from the server is honored even above it
+	MaxBackoff time.Duration
+	// Jitter fraction (0..1) of the backoff that is randomized to spread out concurrent retries
+	Jitter float64
+	// RetryStatus HTTP status codes that are retried
+	RetryStatus []int
+	// RetryNetworkErrors retry timeouts, refused and reset connections and truncated responses
+	RetryNetworkErrors bool
+	// RetryIf optional classifier called for every failed attempt, overrides RetryStatus and RetryNetworkErrors
+	RetryIf func(err error) bool
+}
+
+// DefaultRetryPolicy retries 429 and 5xx responses and network errors up to 4 attempts
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		BaseBackoff: 500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.5,
+		RetryStatus: []int{
+			http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+		RetryNetworkErrors: true,
+	}
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// retryable reports whether err should be retried under the policy
+func (p *RetryPolicy) retryable(err error) bool {
+	if p == nil || err == nil {
+		return false
+	}
+	if p.RetryIf != nil {
+		return p.RetryIf(err)
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		for _, s := range p.RetryStatus {
+			if s == apiErr.StatusCode {
+				return true
+			}
+		}
+		return false
+	}
+	return p.RetryNetworkErrors && isNetworkError(err)
+}
+
+// backoff returns the wait before the next attempt, attempt is the number of the failed attempt starting at 1
+func (p *RetryPolicy) backoff(attempt int, err error) time.Duration {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAfter > 0 {
+		return apiErr.RetryAfter
+	}
+	d := p.BaseBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 && d > 0 {
+		j := p.Jitter
+		if j > 1 {
+			j = 1
+		}
+		d -= time.Duration(j * randFloat64() * float64(d))
+	}
+	return d
+}
+
+// isNetworkError reports transport failures worth another attempt
+func isNetworkError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// sleepCtx waits for d or until ctx is done. It returns false without waiting
+// when the context deadline would pass before d elapses.
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	if deadline, ok := ctx.Deadline(); ok && time.Now().Add(d).After(deadline) {
+		return false
+	}
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+var (
+	rndMu sync.Mutex
+	rnd   = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+func randFloat64() float64 {
+	rndMu.Lock()
+	defer rndMu.Unlock()
+	return rnd.Float64()
+}
@@ -0,0 +1,120 @@
+package coingecko
+
+import (
+	"context"
+	"errors"
+	"golang.org/x/time/rate"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMakeReqRetry(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = w.Write([]byte(`{"gecko_says":"(V3) To the Moon!"}`))
+		}
+	}))
+	defer srv.Close()
+
+	policy := DefaultRetryPolicy()
+	policy.BaseBackoff = time.Millisecond
+	c := NewClient(Config{BaseUrl: srv.URL, RateLimiter: rate.NewLimiter(rate.Inf, 1), Retry: policy})
+	got, err := c.Ping(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.GeckoSays != "(V3) To the Moon!" || atomic.LoadInt32(&calls) != 3 {
+		t.Errorf("got %+v after %d calls", got, calls)
+	}
+}
+
+func TestMakeReqRetryStops(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		policy *RetryPolicy
+		ctx    func() (context.Context, context.CancelFunc)
+		calls  int32
+	}{
+		{
+			name:   "disabled",
+			status: http.StatusServiceUnavailable,
+			calls:  1,
+		},
+		{
+			name:   "not retryable status",
+			status: http.StatusNotFound,
+			policy: DefaultRetryPolicy(),
+			calls:  1,
+		},
+		{
+			name:   "max attempts",
+			status: http.StatusServiceUnavailable,
+			policy: &RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Millisecond, RetryStatus: []int{503}},
+			calls:  3,
+		},
+		{
+			name:   "deadline before backoff",
+			status: http.StatusServiceUnavailable,
+			policy: &RetryPolicy{MaxAttempts: 5, BaseBackoff: time.Minute, RetryStatus: []int{503}},
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), time.Second)
+			},
+			calls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+			ctx, cancel := context.Background(), func() {}
+			if tt.ctx != nil {
+				ctx, cancel = tt.ctx()
+			}
+			defer cancel()
+
+			c := NewClient(Config{BaseUrl: srv.URL, RateLimiter: rate.NewLimiter(rate.Inf, 1), Retry: tt.policy})
+			_, err := c.Ping(ctx)
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+				t.Errorf("got error %v", err)
+			}
+			if got := atomic.LoadInt32(&calls); got != tt.calls {
+				t.Errorf("calls = %d, want %d", got, tt.calls)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	p := &RetryPolicy{BaseBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	want := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	for i, w := range want {
+		if got := p.backoff(i+1, errors.New("x")); got != w*time.Millisecond {
+			t.Errorf("backoff(%d) = %s, want %s", i+1, got, w*time.Millisecond)
+		}
+	}
+	if got := p.backoff(1, &APIError{RetryAfter: 5 * time.Second}); got != 5*time.Second {
+		t.Errorf("Retry-After not honored: %s", got)
+	}
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.backoff(2, errors.New("x")); got < 100*time.Millisecond || got > 200*time.Millisecond {
+			t.Fatalf("jittered backoff out of range: %s", got)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package coingecko
+
+import (
+	"strings"
+)
+
+// Route templates of the endpoints supported by the client, used to apply
+// per-endpoint settings such as cache TTLs to request URLs
+const (
+	RoutePing                            = "/ping"
+	RouteSimplePrice                     = "/simple/price"
+	RouteSimpleSupportedVSCurrencies     = "/simple/supported_vs_currencies"
+	RouteSimpleTokenPrice                = "/simple/token_price/{id}"
+	RouteCoinsList                       = "/coins/list"
+	RouteCoinsMarkets                    = "/coins/markets"
+	RouteCoinsID                         = "/coins/{id}"
+	RouteCoinsIDTickers                  = "/coins/{id}/tickers"
+	RouteCoinsIDHistory                  = "/coins/{id}/history"
+	RouteCoinsIDMarketChart              = "/coins/{id}/market_chart"
+	RouteCoinsIDMarketChartRange         = "/coins/{id}/market_chart/range"
+	RouteCoinsIDOHLC                     = "/coins/{id}/ohlc"
+	RouteCoinsIDOHLCRange                = "/coins/{id}/ohlc/range"
+	RouteCoinsIDContractAddress          = "/coins/{id}/contract/{contract_address}"
+	RouteCoinsIDContractMarketChart      = "/coins/{id}/contract/{contract_address}/market_chart"
+	RouteCoinsIDContractMarketChartRange = "/coins/{id}/contract/{contract_address}/market_chart/range"
+	RouteAssetPlatforms                  = "/asset_platforms"
+	RouteCategoriesList                  = "/coins/categories/list"
+	RouteCategories                      = "/coins/categories"
+	RouteExchanges                       = "/exchanges"
+	RouteExchangesID                     = "/exchanges/{id}"
+	RouteExchangesIDTickers              = "/exchanges/{id}/tickers"
+	RouteExchangesList                   = "/exchanges/list"
+	RouteExchangesIDVolumeChart          = "/exchanges/{id}/volume_chart"
+	RouteExchangesIDVolumeChartRange     = "/exchanges/{id}/volume_chart/range"
+	RouteDerivatives                     = "/derivatives"
+	RouteDerivativesExchanges            = "/derivatives/exchanges"
+	RouteDerivativesExchangesID          = "/derivatives/exchanges/{id}"
+	RouteDerivativesExchangesList        = "/derivatives/exchanges/list"
+	RouteIndexes                         = "/indexes"
+	RouteIndexesMarketID                 = "/indexes/{market_id}/{id}"
+	RouteIndexesList                     = "/indexes/list"
+	RouteNFTsList                        = "/nfts/list"
+	RouteNFTsID                          = "/nfts/{id}"
+	RouteNFTsContractAddress             = "/nfts/{asset_platform_id}/contract/{contract_address}"
+	RouteExchangeRates                   = "/exchange_rates"
+	RouteSearch                          = "/search"
+	RouteGlobal                          = "/global"
+)
+
+var routes = []string{
+	RoutePing,
+	RouteSimplePrice,
+	RouteSimpleSupportedVSCurrencies,
+	RouteSimpleTokenPrice,
+	RouteCoinsList,
+	RouteCoinsMarkets,
+	RouteCoinsID,
+	RouteCoinsIDTickers,
+	RouteCoinsIDHistory,
+	RouteCoinsIDMarketChart,
+	RouteCoinsIDMarketChartRange,
+	RouteCoinsIDOHLC,
+	RouteCoinsIDOHLCRange,
+	RouteCoinsIDContractAddress,
+	RouteCoinsIDContractMarketChart,
+	RouteCoinsIDContractMarketChartRange,
+	RouteAssetPlatforms,
+	RouteCategoriesList,
+	RouteCategories,
+	RouteExchanges,
+	RouteExchangesID,
+	RouteExchangesIDTickers,
+	RouteExchangesList,
+	RouteExchangesIDVolumeChart,
+	RouteExchangesIDVolumeChartRange,
+	RouteDerivatives,
+	RouteDerivativesExchanges,
+	RouteDerivativesExchangesID,
+	RouteDerivativesExchangesList,
+	RouteIndexes,
+	RouteIndexesMarketID,
+	RouteIndexesList,
+	RouteNFTsList,
+	RouteNFTsID,
+	RouteNFTsContractAddress,
+	RouteExchangeRates,
+	RouteSearch,
+	RouteGlobal,
+}
+
+// matchRoute returns the route template for a request path relative to the
+// base URL, or the path itself when no template matches. Literal segments
+// win over placeholders, so /coins/list never matches /coins/{id}.
+func matchRoute(path string) string {
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	best, bestScore := "", -1
+	for _, r := range routes {
+		rsegs := strings.Split(strings.Trim(r, "/"), "/")
+		if len(rsegs) != len(segs) {
+			continue
+		}
+		score := 0
+		for i, s := range rsegs {
+			if strings.HasPrefix(s, "{") {
+				continue
+			}
+			if s != segs[i] {
+				score = -1
+				break
+			}
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = r, score
+		}
+	}
+	if best == "" {
+		return path
+	}
+	return best
+}
+
+// routeOf returns the route template of a full request URL made against the base URL
+func (c *Client) routeOf(rawURL string) string {
+	path := strings.TrimPrefix(rawURL, c.cfg.BaseUrl)
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	return matchRoute(path)
+}
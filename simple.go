@@ -0,0 +1,231 @@
+package coingecko
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SimplePriceRequest /simple/price parameters
+type SimplePriceRequest struct {
+	// Ids coin ids, e.g. bitcoin
+	Ids []string
+	// VsCurrencies target currencies, e.g. usd
+	VsCurrencies []string
+	QuoteOptions
+}
+
+// QuoteOptions include_* and precision parameters shared by simple and token prices
+type QuoteOptions struct {
+	// IncludeMarketCap fills Quote.MarketCap
+	IncludeMarketCap bool
+	// Include24hrVol fills Quote.Volume24h
+	Include24hrVol bool
+	// Include24hrChange fills Quote.Change24h
+	Include24hrChange bool
+	// IncludeLastUpdatedAt fills Quote.LastUpdatedAt
+	IncludeLastUpdatedAt bool
+	// Precision decimal places of the values, 0 to 18 or full, the API default when empty
+	Precision string
+}
+
+// Validate checks the request before it is sent
+func (r SimplePriceRequest) Validate() error {
+	return firstError(
+		requiredList("ids", r.Ids),
+		requiredList("vs_currencies", r.VsCurrencies),
+		validatePrecision(r.Precision),
+	)
+}
+
+// validatePrecision precision of simple price values, 0 to 18 or full
+func validatePrecision(precision string) error {
+	if precision == "" || precision == "full" {
+		return nil
+	}
+	if p, err := strconv.Atoi(precision); err != nil || p < 0 || p > 18 {
+		return invalid("precision", strconv.Quote(precision)+" is not 0 to 18 or full")
+	}
+	return nil
+}
+
+// Quote price of a coin or token in one currency, optional fields are zero unless requested
+type Quote struct {
+	Price         float64
+	MarketCap     float64
+	Volume24h     float64
+	Change24h     float64
+	LastUpdatedAt time.Time
+}
+
+// Quotes quotes by coin id, or contract address for token prices, and currency
+type Quotes map[string]map[string]Quote
+
+// rawQuotes simple price response, e.g. {"bitcoin":{"usd":1,"usd_market_cap":2,"last_updated_at":3}}
+type rawQuotes map[string]map[string]*float64
+
+// quotes splits the flattened currency_field keys into Quotes of the requested currencies
+func (raw rawQuotes) quotes(vsCurrencies []string) Quotes {
+	out := make(Quotes, len(raw))
+	for id, values := range raw {
+		get := func(key string) float64 {
+			if v := values[key]; v != nil {
+				return *v
+			}
+			return 0
+		}
+		var updated time.Time
+		if v := values["last_updated_at"]; v != nil {
+			updated = time.Unix(int64(*v), 0).UTC()
+		}
+		byCurrency := make(map[string]Quote, len(vsCurrencies))
+		for _, vs := range vsCurrencies {
+			vs = strings.ToLower(vs)
+			if _, ok := values[vs]; !ok {
+				continue
+			}
+			byCurrency[vs] = Quote{
+				Price:         get(vs),
+				MarketCap:     get(vs + "_market_cap"),
+				Volume24h:     get(vs + "_24h_vol"),
+				Change24h:     get(vs + "_24h_change"),
+				LastUpdatedAt: updated,
+			}
+		}
+		out[id] = byCurrency
+	}
+	return out
+}
+
+// query adds the options to q
+func (o QuoteOptions) query(q *Query) {
+	q.Bool("include_market_cap", o.IncludeMarketCap).
+		Bool("include_24hr_vol", o.Include24hrVol).
+		Bool("include_24hr_change", o.Include24hrChange).
+		Bool("include_last_updated_at", o.IncludeLastUpdatedAt).
+		Opt("precision", o.Precision)
+}
+
+// SimplePriceQuotes /simple/price with market data options, quotes keyed by coin id and
+// currency. Unlike SimplePrice the calls are never merged by Config.PriceBatch
+func (c *Client) SimplePriceQuotes(ctx context.Context, r SimplePriceRequest) (Quotes, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	var p Params
+	p.Query.Set("ids", strings.Join(r.Ids, ",")).Set("vs_currencies", strings.Join(r.VsCurrencies, ","))
+	r.QuoteOptions.query(&p.Query)
+	raw, err := Do(ctx, c, Endpoint[rawQuotes]{Name: "SimplePriceQuotes", Route: RouteSimplePrice}, p)
+	if err != nil {
+		return nil, err
+	}
+	return raw.quotes(r.VsCurrencies), nil
+}
+
+// TokenPriceOptions optional /simple/token_price parameters
+type TokenPriceOptions struct {
+	QuoteOptions
+	// ChunkSize max contract addresses per request, default 30
+	ChunkSize int
+}
+
+// defaultTokenPriceChunkSize keeps token price URLs of EVM addresses below 2000 bytes
+const defaultTokenPriceChunkSize = 30
+
+// nonEVMPlatforms asset platforms whose contract addresses are sent as given,
+// e.g. case sensitive base58 Solana mints
+var nonEVMPlatforms = map[string]bool{
+	"solana":           true,
+	"tron":             true,
+	"sui":              true,
+	"aptos":            true,
+	"near-protocol":    true,
+	"cardano":          true,
+	"stellar":          true,
+	"the-open-network": true,
+	"algorand":         true,
+	"tezos":            true,
+	"hedera-hashgraph": true,
+	"osmosis":          true,
+}
+
+// normalizeAddress lower cases hex addresses on EVM platforms, which CoinGecko
+// matches and returns in lower case regardless of checksum casing. Addresses on
+// nonEVMPlatforms are kept as they are. A platform missing from the list is
+// treated as EVM, so a 0x hex address on it is lower cased.
+func normalizeAddress(platform string, address string) string {
+	address = strings.TrimSpace(address)
+	if nonEVMPlatforms[platform] || !isEVMAddress(address) {
+		return address
+	}
+	return strings.ToLower(address)
+}
+
+// isEVMAddress reports whether address is 0x followed by 40 hex digits
+func isEVMAddress(address string) bool {
+	if len(address) != 42 || (address[:2] != "0x" && address[:2] != "0X") {
+		return false
+	}
+	for _, r := range address[2:] {
+		if !('0' <= r && r <= '9' || 'a' <= r && r <= 'f' || 'A' <= r && r <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+// SimpleTokenPrice /simple/token_price/{id} quotes of tokens on the asset platform,
+// e.g. ethereum or solana, keyed by contract address as passed in and currency.
+// Long address lists are split into several requests.
+func (c *Client) SimpleTokenPrice(ctx context.Context, platform string, contractAddresses []string,
+	vsCurrencies []string, opts *TokenPriceOptions) (data Quotes, err error) {
+	if opts == nil {
+		opts = &TokenPriceOptions{}
+	}
+	err = firstError(
+		required("asset_platform_id", platform),
+		requiredList("contract_addresses", contractAddresses),
+		requiredList("vs_currencies", vsCurrencies),
+		validatePrecision(opts.Precision),
+		nonNegative("chunk_size", opts.ChunkSize),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ctx, span := c.startSpan(ctx, "SimpleTokenPrice", RouteSimpleTokenPrice)
+	defer func() { endSpan(span, err) }()
+
+	// requested addresses by normalized form, several spellings may map to one token
+	byNormalized := make(map[string][]string, len(contractAddresses))
+	var addresses []string
+	for _, a := range contractAddresses {
+		n := normalizeAddress(platform, a)
+		if _, ok := byNormalized[n]; !ok {
+			addresses = append(addresses, n)
+		}
+		byNormalized[n] = append(byNormalized[n], a)
+	}
+	size := opts.ChunkSize
+	if size == 0 {
+		size = defaultTokenPriceChunkSize
+	}
+	e := Endpoint[rawQuotes]{Name: "SimpleTokenPrice", Route: RouteSimpleTokenPrice}
+	data = make(Quotes, len(contractAddresses))
+	for start := 0; start < len(addresses); start += size {
+		chunk := addresses[start:min(start+size, len(addresses))]
+		p := Params{Path: map[string]string{"id": platform}}
+		p.Query.Set("contract_addresses", strings.Join(chunk, ",")).Set("vs_currencies", strings.Join(vsCurrencies, ","))
+		opts.QuoteOptions.query(&p.Query)
+		raw, err := get(ctx, c, e, p)
+		if err != nil {
+			return nil, err
+		}
+		for address, quotes := range raw.quotes(vsCurrencies) {
+			for _, requested := range byNormalized[normalizeAddress(platform, address)] {
+				data[requested] = quotes
+			}
+		}
+	}
+	return data, nil
+}
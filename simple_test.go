@@ -0,0 +1,112 @@
+package coingecko
+
+import (
+	"context"
+	"errors"
+	"github.com/aibotsoft/coingecko/coingeckotest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSimplePriceQuotes(t *testing.T) {
+	defer srv.Reset()
+	srv.Respond(RouteSimplePrice, coingeckotest.Response{Body: `{
+		"bitcoin":{"usd":67187.33,"usd_market_cap":1317802988326.25,"usd_24h_vol":31260929299.52,"usd_24h_change":3.64,
+			"eur":61838.1,"eur_market_cap":null,"last_updated_at":1711356300},
+		"ethereum":{"usd":3519.1}
+	}`, Times: 1})
+	got, err := c.SimplePriceQuotes(context.Background(), SimplePriceRequest{
+		Ids:          []string{"bitcoin", "ethereum"},
+		VsCurrencies: []string{"USD", "eur", "jpy"},
+		QuoteOptions: QuoteOptions{
+			IncludeMarketCap:     true,
+			Include24hrVol:       true,
+			Include24hrChange:    true,
+			IncludeLastUpdatedAt: true,
+			Precision:            "full",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Quote{Price: 67187.33, MarketCap: 1317802988326.25, Volume24h: 31260929299.52, Change24h: 3.64,
+		LastUpdatedAt: time.Date(2024, 3, 25, 8, 45, 0, 0, time.UTC)}
+	if got["bitcoin"]["usd"] != want {
+		t.Errorf("bitcoin usd = %+v, want %+v", got["bitcoin"]["usd"], want)
+	}
+	if q := got["bitcoin"]["eur"]; q.Price != 61838.1 || q.MarketCap != 0 || !q.LastUpdatedAt.Equal(want.LastUpdatedAt) {
+		t.Errorf("bitcoin eur = %+v", q)
+	}
+	if _, ok := got["bitcoin"]["jpy"]; ok {
+		t.Error("quote for a currency missing from the response")
+	}
+	if q := got["ethereum"]["usd"]; q.Price != 3519.1 || !q.LastUpdatedAt.IsZero() {
+		t.Errorf("ethereum usd = %+v", q)
+	}
+	q := srv.LastQuery(RouteSimplePrice)
+	if q.Get("include_market_cap") != "true" || q.Get("include_24hr_vol") != "true" || q.Get("include_24hr_change") != "true" ||
+		q.Get("include_last_updated_at") != "true" || q.Get("precision") != "full" {
+		t.Errorf("query = %v", q)
+	}
+
+	for _, precision := range []string{"19", "-1", "max"} {
+		_, err := c.SimplePriceQuotes(context.Background(), SimplePriceRequest{Ids: []string{"bitcoin"}, VsCurrencies: []string{"usd"}, QuoteOptions: QuoteOptions{Precision: precision}})
+		if !errors.Is(err, ErrInvalidArgument) {
+			t.Errorf("precision %s: got %v", precision, err)
+		}
+	}
+}
+
+func TestSimpleTokenPrice(t *testing.T) {
+	defer srv.Reset()
+	ctx := context.Background()
+	usdc, weth := "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"
+	got, err := c.SimpleTokenPrice(ctx, "ethereum", []string{usdc, weth, "0xdead"}, []string{"usd"}, &TokenPriceOptions{ChunkSize: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[usdc]["usd"].Price != 0.999825 || got[weth]["usd"].Price != 3512.47 || len(got) != 2 {
+		t.Errorf("got %+v", got)
+	}
+	reqs := srv.Requests(RouteSimpleTokenPrice)
+	if len(reqs) != 2 || reqs[0].Query.Get("contract_addresses") != strings.ToLower(usdc+","+weth) || reqs[1].Query.Get("contract_addresses") != "0xdead" {
+		t.Errorf("requests %+v", reqs)
+	}
+
+	bonk := "DezXAZ8z7PnrnRJjz3wXBoRgixCa6xjnB7YaB1pPB263"
+	got, err = c.SimpleTokenPrice(ctx, "solana", []string{bonk}, []string{"usd", "eur"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[bonk]["eur"].Price != 0.000021 {
+		t.Errorf("got %+v", got)
+	}
+	if q := srv.LastQuery(RouteSimpleTokenPrice); q.Get("contract_addresses") != bonk {
+		t.Errorf("solana address case changed: %v", q)
+	}
+
+	_, err = c.SimpleTokenPrice(ctx, "", []string{usdc}, []string{"usd"}, nil)
+	var invalid *InvalidArgumentError
+	if !errors.As(err, &invalid) || invalid.Field != "asset_platform_id" {
+		t.Errorf("missing platform: got %v", err)
+	}
+}
+
+func TestNormalizeAddress(t *testing.T) {
+	const hex = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	tests := []struct {
+		platform, address, want string
+	}{
+		{"ethereum", hex, strings.ToLower(hex)},
+		{"polygon-pos", " " + hex, strings.ToLower(hex)},
+		{"solana", hex, hex},
+		{"sui", hex, hex},
+		{"ethereum", "DezXAZ8z7PnrnRJjz3wXBoRgixCa6xjnB7YaB1pPB263", "DezXAZ8z7PnrnRJjz3wXBoRgixCa6xjnB7YaB1pPB263"},
+	}
+	for _, tt := range tests {
+		if got := normalizeAddress(tt.platform, tt.address); got != tt.want {
+			t.Errorf("%s %q: got %q, want %q", tt.platform, tt.address, got, tt.want)
+		}
+	}
+}
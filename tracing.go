@@ -0,0 +1,65 @@
+package coingecko
+
+import (
+	"context"
+	"errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName instrumentation scope of the client spans
+const tracerName = "github.com/aibotsoft/coingecko"
+
+// Span attribute keys
+const (
+	AttrEndpoint   = attribute.Key("coingecko.endpoint")
+	AttrCoinID     = attribute.Key("coingecko.coin_id")
+	AttrVsCurrency = attribute.Key("coingecko.vs_currency")
+	AttrAttempt    = attribute.Key("coingecko.attempt")
+	AttrErrorCode  = attribute.Key("coingecko.error_code")
+	AttrCacheHit   = attribute.Key("coingecko.cache_hit")
+	AttrStatusCode = attribute.Key("http.response.status_code")
+)
+
+func newTracer(tp trace.TracerProvider) trace.Tracer {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer(tracerName)
+}
+
+// startSpan opens the span of a public method, e.g. coingecko.CoinsMarket
+func (c *Client) startSpan(ctx context.Context, method string, route string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append([]attribute.KeyValue{AttrEndpoint.String(route)}, attrs...)
+	return c.tracer.Start(ctx, "coingecko."+method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+// endSpan records err on span, including the HTTP status and CoinGecko error code of an *APIError, and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			span.SetAttributes(AttrStatusCode.Int(apiErr.StatusCode))
+			if apiErr.Code != 0 {
+				span.SetAttributes(AttrErrorCode.Int(apiErr.Code))
+			}
+		}
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// coinAttrs attributes for methods scoped to a coin and currency, empty values are skipped
+func coinAttrs(id string, vsCurrency string) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if id != "" {
+		attrs = append(attrs, AttrCoinID.String(id))
+	}
+	if vsCurrency != "" {
+		attrs = append(attrs, AttrVsCurrency.String(vsCurrency))
+	}
+	return attrs
+}
@@ -0,0 +1,78 @@
+package coingecko
+
+import (
+	"context"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"golang.org/x/time/rate"
+	"testing"
+)
+
+func spanAttr(s tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range s.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracing(t *testing.T) {
+	defer srv.Reset()
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	c := NewClient(Config{BaseUrl: srv.URL, RateLimiter: rate.NewLimiter(rate.Inf, 1), TracerProvider: tp})
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+	_, err := c.CoinsIDMarketChart(ctx, CoinsIDMarketChartRequest{ID: "bitcoin", VsCurrency: "usd", Days: "1"})
+	parent.End()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spans := map[string]tracetest.SpanStub{}
+	for _, s := range exporter.GetSpans() {
+		spans[s.Name] = s
+	}
+	method, ok := spans["coingecko.CoinsIDMarketChart"]
+	if !ok {
+		t.Fatalf("method span missing, got %v", spans)
+	}
+	if method.Parent.SpanID() != parent.SpanContext().SpanID() {
+		t.Error("method span is not a child of the caller span")
+	}
+	if spanAttr(method, AttrEndpoint).AsString() != RouteCoinsIDMarketChart ||
+		spanAttr(method, AttrCoinID).AsString() != "bitcoin" ||
+		spanAttr(method, AttrVsCurrency).AsString() != "usd" {
+		t.Errorf("method span attributes %v", method.Attributes)
+	}
+	for _, name := range []string{"coingecko.rate_limiter.wait", "coingecko.http", "coingecko.decode"} {
+		s, ok := spans[name]
+		if !ok {
+			t.Errorf("%s span missing", name)
+			continue
+		}
+		if s.Parent.SpanID() != method.SpanContext.SpanID() {
+			t.Errorf("%s is not a child of the method span", name)
+		}
+	}
+	if spanAttr(spans["coingecko.http"], AttrStatusCode).AsInt64() != 200 {
+		t.Errorf("http span attributes %v", spans["coingecko.http"].Attributes)
+	}
+
+	exporter.Reset()
+	srv.Error("/coins/{id}/market_chart", 404, 0, "coin not found", 1)
+	if _, err := c.CoinsIDMarketChart(ctx, CoinsIDMarketChartRequest{ID: "nope", VsCurrency: "usd", Days: "1"}); err == nil {
+		t.Fatal("want error")
+	}
+	for _, s := range exporter.GetSpans() {
+		switch s.Name {
+		case "coingecko.http", "coingecko.CoinsIDMarketChart":
+			if s.Status.Code != codes.Error || spanAttr(s, AttrStatusCode).AsInt64() != 404 || len(s.Events) == 0 {
+				t.Errorf("%s span of failed call %+v", s.Name, s)
+			}
+		}
+	}
+}
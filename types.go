@@ -88,28 +88,19 @@ type CoinsIDMarketChart struct {
 // CoinsIDStatusUpdates
 
 // CoinsIDContractAddress https://api.coingecko.com/api/v3/coins/{id}/contract/{contract_address}
-// type CoinsIDContractAddress struct {
-// 	ID                  string           `json:"id"`
-// 	Symbol              string           `json:"symbol"`
-// 	Name                string           `json:"name"`
-// 	BlockTimeInMin      uint16           `json:"block_time_in_minutes"`
-// 	Categories          []string         `json:"categories"`
-// 	Localization        LocalizationItem `json:"localization"`
-// 	Description         DescriptionItem  `json:"description"`
-// 	Links               LinksItem        `json:"links"`
-// 	Image               ImageItem        `json:"image"`
-// 	CountryOrigin       string           `json:"country_origin"`
-// 	GenesisDate         string           `json:"genesis_date"`
-// 	ContractAddress     string           `json:"contract_address"`
-// 	MarketCapRank       uint16           `json:"market_cap_rank"`
-// 	CoinGeckoRank       uint16           `json:"coingecko_rank"`
-// 	CoinGeckoScore      float32          `json:"coingecko_score"`
-// 	DeveloperScore      float32          `json:"developer_score"`
-// 	CommunityScore      float32          `json:"community_score"`
-// 	LiquidityScore      float32          `json:"liquidity_score"`
-// 	PublicInterestScore float32          `json:"public_interest_score"`
-// 	MarketData          `json:"market_data"`
-// }
+type CoinsIDContractAddress struct {
+	CoinsID
+	AssetPlatformID string                    `json:"asset_platform_id"`
+	Platforms       map[string]string         `json:"platforms"`
+	DetailPlatforms map[string]DetailPlatform `json:"detail_platforms"`
+	ContractAddress string                    `json:"contract_address"`
+}
+
+// DetailPlatform contract of a token on one asset platform
+type DetailPlatform struct {
+	DecimalPlace    *int   `json:"decimal_place"`
+	ContractAddress string `json:"contract_address"`
+}
 
 // EventsCountries https://api.coingecko.com/api/v3/events/countries
 type EventsCountries struct {
@@ -159,6 +150,45 @@ type ExchangesItem struct {
 	TradeVolume24HBtcNormalized float64 `json:"trade_volume_24h_btc_normalized"`
 }
 
+// ExchangeDetail https://api.coingecko.com/api/v3/exchanges/binance
+type ExchangeDetail struct {
+	Name                        string             `json:"name"`
+	YearEstablished             int64              `json:"year_established"`
+	Country                     string             `json:"country"`
+	Description                 string             `json:"description"`
+	Url                         string             `json:"url"`
+	Image                       string             `json:"image"`
+	FacebookUrl                 string             `json:"facebook_url"`
+	RedditUrl                   string             `json:"reddit_url"`
+	TelegramUrl                 string             `json:"telegram_url"`
+	SlackUrl                    string             `json:"slack_url"`
+	OtherUrl1                   string             `json:"other_url_1"`
+	OtherUrl2                   string             `json:"other_url_2"`
+	TwitterHandle               string             `json:"twitter_handle"`
+	HasTradingIncentive         bool               `json:"has_trading_incentive"`
+	Centralized                 bool               `json:"centralized"`
+	PublicNotice                string             `json:"public_notice"`
+	AlertNotice                 string             `json:"alert_notice"`
+	TrustScore                  int64              `json:"trust_score"`
+	TrustScoreRank              int64              `json:"trust_score_rank"`
+	TradeVolume24HBtc           float64            `json:"trade_volume_24h_btc"`
+	TradeVolume24HBtcNormalized float64            `json:"trade_volume_24h_btc_normalized"`
+	Tickers                     []TickerItem       `json:"tickers"`
+	StatusUpdates               []StatusUpdateItem `json:"status_updates"`
+}
+
+// ExchangeTickers https://api.coingecko.com/api/v3/exchanges/binance/tickers
+type ExchangeTickers struct {
+	Name    string       `json:"name"`
+	Tickers []TickerItem `json:"tickers"`
+}
+
+// ExchangeListItem https://api.coingecko.com/api/v3/exchanges/list
+type ExchangeListItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
 // GlobalResponse https://api.coingecko.com/api/v3/global
 type GlobalResponse struct {
 	Data Global `json:"data"`
@@ -189,6 +219,8 @@ type OrderType struct {
 	GeckoAsc      string
 	VolumeAsc     string
 	VolumeDesc    string
+	IDAsc         string
+	IDDesc        string
 }
 
 // OrderTypeObject for certain order
@@ -199,6 +231,8 @@ var OrderTypeObject = &OrderType{
 	GeckoAsc:      "gecko_asc",
 	VolumeAsc:     "volume_asc",
 	VolumeDesc:    "volume_desc",
+	IDAsc:         "id_asc",
+	IDDesc:        "id_desc",
 }
 
 // PriceChangePercentage
@@ -470,3 +504,168 @@ type Global struct {
 	MarketCapPercentage             AllCurrencies `json:"market_cap_percentage"`
 	UpdatedAt                       int64         `json:"updated_at"`
 }
+
+// DerivativeTicker https://api.coingecko.com/api/v3/derivatives
+type DerivativeTicker struct {
+	Market                   string    `json:"market"`
+	Symbol                   string    `json:"symbol"`
+	IndexID                  string    `json:"index_id"`
+	Price                    FlexFloat `json:"price"`
+	PricePercentageChange24h FlexFloat `json:"price_percentage_change_24h"`
+	ContractType             string    `json:"contract_type"`
+	Index                    FlexFloat `json:"index"`
+	Basis                    FlexFloat `json:"basis"`
+	Spread                   FlexFloat `json:"spread"`
+	FundingRate              FlexFloat `json:"funding_rate"`
+	OpenInterest             FlexFloat `json:"open_interest"`
+	Volume24h                FlexFloat `json:"volume_24h"`
+	LastTradedAt             UnixTime  `json:"last_traded_at"`
+	ExpiredAt                UnixTime  `json:"expired_at"`
+}
+
+// DerivativesExchange https://api.coingecko.com/api/v3/derivatives/exchanges
+type DerivativesExchange struct {
+	ID                     string    `json:"id"`
+	Name                   string    `json:"name"`
+	OpenInterestBtc        FlexFloat `json:"open_interest_btc"`
+	TradeVolume24hBtc      FlexFloat `json:"trade_volume_24h_btc"`
+	NumberOfPerpetualPairs int64     `json:"number_of_perpetual_pairs"`
+	NumberOfFuturesPairs   int64     `json:"number_of_futures_pairs"`
+	Image                  string    `json:"image"`
+	YearEstablished        *int64    `json:"year_established"`
+	Country                string    `json:"country"`
+	Description            string    `json:"description"`
+	Url                    string    `json:"url"`
+}
+
+// DerivativesExchangeDetail https://api.coingecko.com/api/v3/derivatives/exchanges/binance_futures?include_tickers=all
+type DerivativesExchangeDetail struct {
+	DerivativesExchange
+	Tickers []DerivativesExchangeTicker `json:"tickers"`
+}
+
+// DerivativesExchangeTicker ticker of a derivatives exchange
+type DerivativesExchangeTicker struct {
+	Symbol               string    `json:"symbol"`
+	Base                 string    `json:"base"`
+	Target               string    `json:"target"`
+	CoinID               string    `json:"coin_id"`
+	TargetCoinID         string    `json:"target_coin_id"`
+	TradeUrl             string    `json:"trade_url"`
+	ContractType         string    `json:"contract_type"`
+	Last                 FlexFloat `json:"last"`
+	H24PercentageChange  FlexFloat `json:"h24_percentage_change"`
+	Index                FlexFloat `json:"index"`
+	IndexBasisPercentage FlexFloat `json:"index_basis_percentage"`
+	BidAskSpread         FlexFloat `json:"bid_ask_spread"`
+	FundingRate          FlexFloat `json:"funding_rate"`
+	OpenInterestUsd      FlexFloat `json:"open_interest_usd"`
+	H24Volume            FlexFloat `json:"h24_volume"`
+	ConvertedVolume      struct {
+		BTC FlexFloat `json:"btc"`
+		ETH FlexFloat `json:"eth"`
+		USD FlexFloat `json:"usd"`
+	} `json:"converted_volume"`
+	ConvertedLast struct {
+		BTC FlexFloat `json:"btc"`
+		ETH FlexFloat `json:"eth"`
+		USD FlexFloat `json:"usd"`
+	} `json:"converted_last"`
+	LastTraded UnixTime `json:"last_traded"`
+	ExpiredAt  UnixTime `json:"expired_at"`
+}
+
+// DerivativesExchangeListItem https://api.coingecko.com/api/v3/derivatives/exchanges/list
+type DerivativesExchangeListItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Index https://api.coingecko.com/api/v3/indexes
+type Index struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Market string `json:"market"`
+	// Last nil when CoinGecko has no current value
+	Last                  *FlexFloat `json:"last"`
+	IsMultiAssetComposite *bool      `json:"is_multi_asset_composite"`
+}
+
+// IndexListItem https://api.coingecko.com/api/v3/indexes/list
+type IndexListItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// NFTListItem https://api.coingecko.com/api/v3/nfts/list
+type NFTListItem struct {
+	ID              string `json:"id"`
+	ContractAddress string `json:"contract_address"`
+	Name            string `json:"name"`
+	AssetPlatformID string `json:"asset_platform_id"`
+	Symbol          string `json:"symbol"`
+}
+
+// NFTValue value in the native currency of the collection and in usd
+type NFTValue struct {
+	NativeCurrency FlexFloat `json:"native_currency"`
+	USD            FlexFloat `json:"usd"`
+}
+
+// NFTCollection https://api.coingecko.com/api/v3/nfts/pudgy-penguins
+type NFTCollection struct {
+	ID                                         string    `json:"id"`
+	ContractAddress                            string    `json:"contract_address"`
+	AssetPlatformID                            string    `json:"asset_platform_id"`
+	Name                                       string    `json:"name"`
+	Symbol                                     string    `json:"symbol"`
+	Image                                      NFTImage  `json:"image"`
+	Description                                string    `json:"description"`
+	NativeCurrency                             string    `json:"native_currency"`
+	NativeCurrencySymbol                       string    `json:"native_currency_symbol"`
+	FloorPrice                                 NFTValue  `json:"floor_price"`
+	MarketCap                                  NFTValue  `json:"market_cap"`
+	Volume24h                                  NFTValue  `json:"volume_24h"`
+	FloorPriceInUsd24hPercentageChange         FlexFloat `json:"floor_price_in_usd_24h_percentage_change"`
+	FloorPrice24hPercentageChange              NFTValue  `json:"floor_price_24h_percentage_change"`
+	MarketCap24hPercentageChange               NFTValue  `json:"market_cap_24h_percentage_change"`
+	Volume24hPercentageChange                  NFTValue  `json:"volume_24h_percentage_change"`
+	FloorPrice7dPercentageChange               NFTValue  `json:"floor_price_7d_percentage_change"`
+	FloorPrice14dPercentageChange              NFTValue  `json:"floor_price_14d_percentage_change"`
+	FloorPrice30dPercentageChange              NFTValue  `json:"floor_price_30d_percentage_change"`
+	FloorPrice60dPercentageChange              NFTValue  `json:"floor_price_60d_percentage_change"`
+	FloorPrice1yPercentageChange               NFTValue  `json:"floor_price_1y_percentage_change"`
+	NumberOfUniqueAddresses                    int64     `json:"number_of_unique_addresses"`
+	NumberOfUniqueAddresses24hPercentageChange FlexFloat `json:"number_of_unique_addresses_24h_percentage_change"`
+	VolumeInUsd24hPercentageChange             FlexFloat `json:"volume_in_usd_24h_percentage_change"`
+	TotalSupply                                FlexFloat `json:"total_supply"`
+	OneDaySales                                FlexFloat `json:"one_day_sales"`
+	OneDaySales24hPercentageChange             FlexFloat `json:"one_day_sales_24h_percentage_change"`
+	OneDayAverageSalePrice                     FlexFloat `json:"one_day_average_sale_price"`
+	OneDayAverageSalePrice24hPercentageChange  FlexFloat `json:"one_day_average_sale_price_24h_percentage_change"`
+	Links                                      NFTLinks  `json:"links"`
+	Explorers                                  []struct {
+		Name string `json:"name"`
+		Link string `json:"link"`
+	} `json:"explorers"`
+	UserFavoritesCount  int64    `json:"user_favorites_count"`
+	Ath                 NFTValue `json:"ath"`
+	AthChangePercentage NFTValue `json:"ath_change_percentage"`
+	AthDate             struct {
+		NativeCurrency string `json:"native_currency"`
+		USD            string `json:"usd"`
+	} `json:"ath_date"`
+}
+
+// NFTImage image urls of a collection
+type NFTImage struct {
+	Small   string `json:"small"`
+	Small2x string `json:"small_2x"`
+}
+
+// NFTLinks links of a collection
+type NFTLinks struct {
+	Homepage string `json:"homepage"`
+	Twitter  string `json:"twitter"`
+	Discord  string `json:"discord"`
+}
@@ -0,0 +1,145 @@
+package coingecko
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HistoryDateLayout layout of the CoinsIDHistory date, dd-mm-yyyy
+const HistoryDateLayout = "02-01-2006"
+
+// invalid *InvalidArgumentError for field
+func invalid(field string, reason string) error {
+	return &InvalidArgumentError{Field: field, Reason: reason}
+}
+
+// required rejects an empty value
+func required(field string, value string) error {
+	if value == "" {
+		return invalid(field, "is required")
+	}
+	return nil
+}
+
+// requiredList rejects an empty list or a list with empty items
+func requiredList(field string, values []string) error {
+	if len(values) == 0 {
+		return invalid(field, "is required")
+	}
+	return optionalList(field, values)
+}
+
+// optionalList rejects empty items of a list
+func optionalList(field string, values []string) error {
+	for _, v := range values {
+		if v == "" {
+			return invalid(field, "contains an empty item")
+		}
+	}
+	return nil
+}
+
+// nonNegative rejects a negative value
+func nonNegative(field string, i int) error {
+	if i < 0 {
+		return invalid(field, "must not be negative")
+	}
+	return nil
+}
+
+// atMost rejects a value above max
+func atMost(field string, i int, max int) error {
+	if i > max {
+		return invalid(field, "must not exceed "+strconv.Itoa(max))
+	}
+	return nil
+}
+
+// oneOf rejects a non empty value missing from allowed
+func oneOf(field string, value string, allowed ...string) error {
+	if value == "" {
+		return nil
+	}
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return invalid(field, strconv.Quote(value)+" is not one of "+strings.Join(allowed, ", "))
+}
+
+// firstError first non nil error
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateDays days of a chart, a positive number or max
+func validateDays(days string) error {
+	if days == "" {
+		return invalid("days", "is required")
+	}
+	if days == "max" {
+		return nil
+	}
+	if d, err := strconv.ParseFloat(days, 64); err != nil || d <= 0 {
+		return invalid("days", strconv.Quote(days)+" is not a positive number or max")
+	}
+	return nil
+}
+
+// validateHistoryDate date in HistoryDateLayout
+func validateHistoryDate(date string) error {
+	if date == "" {
+		return invalid("date", "is required")
+	}
+	if _, err := time.Parse(HistoryDateLayout, date); err != nil {
+		return invalid("date", strconv.Quote(date)+" is not a dd-mm-yyyy date")
+	}
+	return nil
+}
+
+// Validate checks the request before it is sent
+func (r CoinsMarketRequest) Validate() error {
+	return firstError(
+		required("vs_currency", r.VsCurrency),
+		optionalList("ids", r.Ids),
+		oneOf("order", r.Order, OrderTypeObject.MarketCapDesc, OrderTypeObject.MarketCapAsc, OrderTypeObject.GeckoDesc,
+			OrderTypeObject.GeckoAsc, OrderTypeObject.VolumeAsc, OrderTypeObject.VolumeDesc, OrderTypeObject.IDAsc,
+			OrderTypeObject.IDDesc),
+		nonNegative("per_page", r.PerPage),
+		atMost("per_page", r.PerPage, 250),
+		nonNegative("page", r.Page),
+		optionalList("price_change_percentage", r.PriceChangePercentage),
+	)
+}
+
+// Validate checks the request before it is sent
+func (r CoinsIDRequest) Validate() error {
+	return required("id", r.ID)
+}
+
+// Validate checks the request before it is sent
+func (r CoinsIDTickersRequest) Validate() error {
+	return firstError(
+		required("id", r.ID),
+		optionalList("exchange_ids", r.ExchangeIds),
+		nonNegative("page", r.Page),
+		oneOf("order", string(r.Order), string(TrustScoreDesc), string(TrustScoreAsc), string(VolumeDesc), string(VolumeAsc)),
+	)
+}
+
+// Validate checks the request before it is sent
+func (r CoinsIDMarketChartRequest) Validate() error {
+	return firstError(
+		required("id", r.ID),
+		required("vs_currency", r.VsCurrency),
+		validateDays(r.Days),
+		oneOf("interval", r.Interval, "5m", "hourly", "daily"),
+	)
+}
@@ -0,0 +1,124 @@
+package coingecko
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/aibotsoft/coingecko/coingeckotest"
+	"testing"
+)
+
+func TestInvalidArgument(t *testing.T) {
+	defer srv.Reset()
+	ctx := context.Background()
+	tests := []struct {
+		name  string
+		call  func() error
+		field string
+	}{
+		{"SimplePrice no ids", func() error { _, err := c.SimplePrice(ctx, nil, []string{"usd"}); return err }, "ids"},
+		{"SimplePrice empty id", func() error { _, err := c.SimplePrice(ctx, []string{"bitcoin", ""}, []string{"usd"}); return err }, "ids"},
+		{"SimplePrice no currencies", func() error { _, err := c.SimplePrice(ctx, []string{"bitcoin"}, nil); return err }, "vs_currencies"},
+		{"SimpleSinglePrice", func() error { _, err := c.SimpleSinglePrice(ctx, "bitcoin", ""); return err }, "vs_currency"},
+		{"CoinsMarket currency", func() error { _, err := c.CoinsMarket(ctx, CoinsMarketRequest{}); return err }, "vs_currency"},
+		{"CoinsMarket order", func() error {
+			_, err := c.CoinsMarket(ctx, CoinsMarketRequest{VsCurrency: "usd", Order: "price_desc"})
+			return err
+		}, "order"},
+		{"CoinsMarket per_page", func() error {
+			_, err := c.CoinsMarket(ctx, CoinsMarketRequest{VsCurrency: "usd", PerPage: 1000})
+			return err
+		}, "per_page"},
+		{"CoinsMarket page", func() error {
+			_, err := c.CoinsMarket(ctx, CoinsMarketRequest{VsCurrency: "usd", Page: -1})
+			return err
+		}, "page"},
+		{"CoinsID", func() error { _, err := c.CoinsID(ctx, CoinsIDRequest{}); return err }, "id"},
+		{"CoinsIDTickers id", func() error { _, err := c.CoinsIDTickers(ctx, CoinsIDTickersRequest{}); return err }, "id"},
+		{"CoinsIDTickers order", func() error {
+			_, err := c.CoinsIDTickers(ctx, CoinsIDTickersRequest{ID: "bitcoin", Order: "name"})
+			return err
+		}, "order"},
+		{"CoinsIDHistory id", func() error { _, err := c.CoinsIDHistory(ctx, "", "30-12-2018", false); return err }, "id"},
+		{"CoinsIDHistory no date", func() error { _, err := c.CoinsIDHistory(ctx, "bitcoin", "", false); return err }, "date"},
+		{"CoinsIDHistory iso date", func() error { _, err := c.CoinsIDHistory(ctx, "bitcoin", "2018-12-30", false); return err }, "date"},
+		{"CoinsIDHistory bad day", func() error { _, err := c.CoinsIDHistory(ctx, "bitcoin", "32-12-2018", false); return err }, "date"},
+		{"CoinsIDMarketChart days", func() error {
+			_, err := c.CoinsIDMarketChart(ctx, CoinsIDMarketChartRequest{ID: "bitcoin", VsCurrency: "usd", Days: "week"})
+			return err
+		}, "days"},
+		{"CoinsIDMarketChart interval", func() error {
+			_, err := c.CoinsIDMarketChart(ctx, CoinsIDMarketChartRequest{ID: "bitcoin", VsCurrency: "usd", Days: "1", Interval: "weekly"})
+			return err
+		}, "interval"},
+		{"Exchanges", func() error { _, err := c.Exchanges(ctx, -1, 1); return err }, "per_page"},
+		{"ExchangesID", func() error { _, err := c.ExchangesID(ctx, ""); return err }, "id"},
+		{"Search", func() error { _, err := c.Search(ctx, ""); return err }, "query"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.call()
+			var invalid *InvalidArgumentError
+			if !errors.Is(err, ErrInvalidArgument) || !errors.As(err, &invalid) || invalid.Field != tt.field {
+				t.Fatalf("got %v, want invalid %s", err, tt.field)
+			}
+		})
+	}
+	if reqs := srv.Requests(""); len(reqs) != 0 {
+		t.Errorf("invalid arguments reached the server: %v", reqs)
+	}
+}
+
+func TestValidOrders(t *testing.T) {
+	for _, order := range []string{OrderTypeObject.IDAsc, OrderTypeObject.IDDesc, OrderTypeObject.VolumeAsc, OrderTypeObject.GeckoDesc} {
+		if err := (CoinsMarketRequest{VsCurrency: "usd", Order: order}).Validate(); err != nil {
+			t.Errorf("CoinsMarket order %s: %v", order, err)
+		}
+	}
+	for _, order := range []CoinsIDTickersOrder{TrustScoreDesc, TrustScoreAsc, VolumeDesc, VolumeAsc} {
+		if err := (CoinsIDTickersRequest{ID: "bitcoin", Order: order}).Validate(); err != nil {
+			t.Errorf("CoinsIDTickers order %s: %v", order, err)
+		}
+	}
+}
+
+func TestErrorPropagation(t *testing.T) {
+	defer srv.Reset()
+	ctx := context.Background()
+	tests := []struct {
+		route string
+		call  func() error
+	}{
+		{RoutePing, func() error { _, err := c.Ping(ctx); return err }},
+		{RouteSimplePrice, func() error { _, err := c.SimplePrice(ctx, []string{"bitcoin"}, []string{"usd"}); return err }},
+		{RouteSimpleSupportedVSCurrencies, func() error { _, err := c.SimpleSupportedVSCurrencies(ctx); return err }},
+		{RouteCoinsList, func() error { _, err := c.CoinsList(ctx); return err }},
+		{RouteCoinsMarkets, func() error { _, err := c.CoinsMarket(ctx, CoinsMarketRequest{VsCurrency: "usd"}); return err }},
+		{RouteCoinsID, func() error { _, err := c.CoinsID(ctx, CoinsIDRequest{ID: "bitcoin"}); return err }},
+		{RouteCoinsIDTickers, func() error { _, err := c.CoinsIDTickers(ctx, CoinsIDTickersRequest{ID: "bitcoin"}); return err }},
+		{RouteCoinsIDHistory, func() error { _, err := c.CoinsIDHistory(ctx, "bitcoin", "30-12-2018", false); return err }},
+		{RouteCoinsIDMarketChart, func() error {
+			_, err := c.CoinsIDMarketChart(ctx, CoinsIDMarketChartRequest{ID: "bitcoin", VsCurrency: "usd", Days: "1"})
+			return err
+		}},
+		{RouteCategoriesList, func() error { _, err := c.CategoriesList(ctx); return err }},
+		{RouteCategories, func() error { _, err := c.Categories(ctx); return err }},
+		{RouteExchanges, func() error { _, err := c.Exchanges(ctx, 10, 1); return err }},
+		{RouteExchangeRates, func() error { _, err := c.ExchangeRates(ctx); return err }},
+		{RouteSearch, func() error { _, err := c.Search(ctx, "btc"); return err }},
+		{RouteGlobal, func() error { _, err := c.Global(ctx); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.route, func(t *testing.T) {
+			srv.Error(tt.route, 500, 0, "internal error", 1)
+			if err := tt.call(); !errors.Is(err, ErrServer) {
+				t.Errorf("server error: got %v", err)
+			}
+			srv.Respond(tt.route, coingeckotest.Response{Body: `{"broken": }`, Times: 1})
+			var syntaxErr *json.SyntaxError
+			if err := tt.call(); !errors.As(err, &syntaxErr) {
+				t.Errorf("decode error: got %v", err)
+			}
+		})
+	}
+}